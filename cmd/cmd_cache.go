@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/phuslu/log"
+
+	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/internal/parser"
+)
+
+// cacheCmd builds the binary cache of configured datasets
+func cacheCmd(_ context.Context, flags cmdFlags) error {
+	cfg, err := config.NewConfig(flags.Config)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	file, states, err := parser.BuildCache(cfg.Parser)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("file", file).Int("states", states).Dur("elapsed", time.Since(start)).Msg("dataset cache built")
+
+	return nil
+}
@@ -55,7 +55,7 @@ func serve(ctx context.Context, cfg *config.Config) error {
 		return err
 	}
 
-	srv, err := server.New(application, cfg.GRPC, cfg.Exchange.InfoFile)
+	srv, err := server.New(application, cfg.GRPC)
 	if err != nil {
 		return err
 	}
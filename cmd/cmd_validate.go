@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+	"github.com/phuslu/log"
+
+	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/internal/parser"
+)
+
+var errInvalidDatasets = errors.New("datasets have problems")
+
+// validateCmd reports problems of configured datasets, it fails if any dataset has problems
+func validateCmd(_ context.Context, flags cmdFlags) error {
+	cfg, err := config.NewConfig(flags.Config)
+	if err != nil {
+		return err
+	}
+
+	reports, err := parser.Validate(cfg.Parser)
+	if err != nil {
+		return err
+	}
+
+	for i := range reports {
+		if !reports[i].Valid() {
+			return errInvalidDatasets
+		}
+	}
+
+	log.Info().Int("datasets", len(reports)).Msg("datasets are valid")
+
+	return nil
+}
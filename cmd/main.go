@@ -31,7 +31,7 @@ func main() {
 }
 
 var (
-	errNoCommand      = errors.New("no command provided (serve, upload, version, help)")
+	errNoCommand      = errors.New("no command provided (serve, cache, validate, upload, version, help)")
 	errUnimplemented  = errors.New("unimplemented")
 	errUnknownCommand = errors.New("unknown command")
 )
@@ -49,6 +49,10 @@ func runMain(ctx context.Context, args []string) error {
 	switch cmd := args[0]; cmd {
 	case "serve":
 		return serveCmd(ctx, flags)
+	case "cache":
+		return cacheCmd(ctx, flags)
+	case "validate":
+		return validateCmd(ctx, flags)
 	case "help":
 		panic(errUnimplemented)
 	default:
@@ -27,8 +27,37 @@ type ApplicationConfig struct {
 }
 
 type ExchangeConfig struct {
-	InfoFile   string  `default:"./data/exchange.json"`
-	Commission float64 `default:"0.1"`
+	InfoFile string `default:"./data/exchange.json"`
+	// MakerCommission and TakerCommission are fee percentages for resting and crossing orders.
+	// Negative maker commission is a rebate
+	MakerCommission float64 `default:"0.1"`
+	TakerCommission float64 `default:"0.1"`
+	// Commissions overrides maker and taker commissions per symbol
+	Commissions []CommissionConfig
+	// MarketPrice is a kline price used to fill market orders: open, close or worst (high for buy, low for sell)
+	MarketPrice string `default:"close"`
+	// MarketBuffer is a percentage added to the estimated market buy cost while the order is locked
+	MarketBuffer float64 `default:"5"`
+	// ParticipationRate is a max percentage of kline base volume an order can fill on. Zero disables the limit
+	ParticipationRate float64 `default:"0"`
+	// PricePath is a model of price movement inside a kline used to order fills: ohlc, olhc, nearest or random
+	PricePath     string `default:"ohlc"`
+	PricePathSeed int64  `default:"1"`
+	// Clock is a policy of the replay clock: always running, running while orders are open
+	// or manual, advanced by step requests only
+	Clock string `default:"orders"`
+}
+
+type CommissionConfig struct {
+	Symbol string
+	Maker  float64
+	Taker  float64
+}
+
+type DatasetConfig struct {
+	// Symbol overrides a symbol column of the file
+	Symbol string
+	File   string
 }
 
 type WSConfig struct {
@@ -50,9 +79,38 @@ type LoggerConfig struct {
 }
 
 type ParserConfig struct {
-	File          string
+	// File is a csv with header, a Binance kline zip or csv archive, a directory of archives
+	// or a .gen.json spec of synthetic klines
+	File string
+	// Datasets lists one file per symbol merged by timestamp, File is used when it's empty
+	Datasets []DatasetConfig
+	// Books lists L2 order book update files per symbol used to fill orders taking liquidity
+	Books []DatasetConfig
+	// CacheDir keeps binary caches of datasets built on first load
+	CacheDir      string `default:"./data/cache"`
+	Validation    ValidationConfig
 	ListenerDelay time.Duration `default:"3ms"`
-	Offset        int64         `default:"0"`
+	// Offset and End are timestamps the replay starts and ends at, zero End replays to the end of datasets
+	Offset int64 `default:"0"`
+	End    int64 `default:"0"`
+	// Symbols limits replayed symbols, all symbols are replayed when it's empty
+	Symbols []string
+	// Step replays timestamps only on client requests instead of the delay
+	Step bool `default:"false"`
+	// Realtime replays timestamps at the speed of the dataset shifted to the present instead of the delay.
+	// It runs the always clock and can't be combined with step or the manual clock.
+	// Klines are sent and stamped at their close time, actions at the wall time
+	Realtime bool `default:"false"`
+}
+
+// ValidationConfig sets policies of dataset problems found at load time:
+// ignore, fail, skip, forward_fill or interpolate. Skip drops invalid states and leaves gaps as is.
+// Forward fill flattens prices of invalid states and keeps their volume, zero volume klines stay zero volume
+type ValidationConfig struct {
+	Gaps       string `default:"ignore"`
+	Duplicates string `default:"skip"`
+	OHLC       string `default:"ignore"`
+	ZeroVolume string `default:"ignore"`
 }
 
 // NewConfig loads values from environment variables and returns loaded configuration.
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/cornelk/hashmap"
@@ -9,7 +10,9 @@ import (
 	"github.com/phuslu/log"
 
 	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/gen/proto/api"
 	"github.com/xenking/exchange-emulator/internal/exchange"
+	"github.com/xenking/exchange-emulator/internal/info"
 	"github.com/xenking/exchange-emulator/internal/parser"
 	"github.com/xenking/exchange-emulator/internal/ws"
 	"github.com/xenking/exchange-emulator/pkg/logger"
@@ -18,6 +21,7 @@ import (
 type App struct {
 	parser   *parser.Parser
 	config   *config.Config
+	info     *info.Info
 	orders   <-chan *ws.UserConn
 	prices   <-chan *ws.UserConn
 	shutdown chan shutdownHandler
@@ -25,11 +29,21 @@ type App struct {
 }
 
 func New(orders, prices <-chan *ws.UserConn, cfg *config.Config) (*App, error) {
+	if cfg.Parser.Realtime && (cfg.Parser.Step || cfg.Exchange.Clock == exchange.ClockManual) {
+		return nil, errors.Wrap(ErrInvalidConfig, "realtime replay can't be stepped")
+	}
+
+	exchangeInfo, err := info.Load(cfg.Exchange.InfoFile)
+	if err != nil {
+		return nil, err
+	}
+
 	p, err := parser.New(cfg.Parser)
 
 	app := &App{
 		parser:   p,
 		config:   cfg,
+		info:     exchangeInfo,
 		orders:   orders,
 		prices:   prices,
 		shutdown: make(chan shutdownHandler, 128),
@@ -39,6 +53,11 @@ func New(orders, prices <-chan *ws.UserConn, cfg *config.Config) (*App, error) {
 	return app, err
 }
 
+var (
+	ErrInvalidConfig  = errors.New("invalid config")
+	ErrInvalidSession = errors.New("invalid session config")
+)
+
 func (a *App) Start(ctx context.Context) {
 	go a.startShutdownHandler(ctx)
 
@@ -68,6 +87,11 @@ func (a *App) Start(ctx context.Context) {
 	}
 }
 
+// ExchangeInfo returns exchange info the orders are validated against
+func (a *App) ExchangeInfo() *info.Info {
+	return a.info
+}
+
 func (a *App) GetClient(userID string) (*Client, error) {
 	c, ok := a.clients.Get(userID)
 	if !ok {
@@ -79,23 +103,155 @@ func (a *App) GetClient(userID string) (*Client, error) {
 func (a *App) GetOrCreateClient(ctx context.Context, userID string) (*Client, error) {
 	client, ok := a.clients.Get(userID)
 	if !ok {
+		var err error
+		if client, err = a.newClient(ctx, userID, a.config); err != nil {
+			return nil, err
+		}
+	}
 
-		listener := a.parser.NewListener()
-		client = exchange.New(ctx, a.config, listener, logger.NewUser(userID))
+	return &Client{Client: client}, nil
+}
+
+// StartSession replaces the client of the user with a new one configured by the session.
+// WS connections of the replaced client are moved to the new one.
+func (a *App) StartSession(ctx context.Context, userID string, session *api.SessionConfig) (*Client, error) {
+	cfg, err := sessionConfig(a.config, session)
+	if err != nil {
+		return nil, err
+	}
 
-		log.Debug().Str("user", userID).Msg("new exchange client")
-		a.clients.Set(userID, client)
-		a.shutdown <- shutdownHandler{
-			shutdown: client.Shutdown(),
-			clientID: userID,
+	old, replaced := a.clients.Get(userID)
+	c, err := a.newClient(ctx, userID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	client := &Client{Client: c}
+	if replaced {
+		orders, prices := old.Detach(ctx)
+		old.Close()
+		if orders != nil {
+			client.SetOrdersConnection(orders)
+		}
+		if prices != nil {
+			client.SetPricesConnection(prices)
 		}
 	}
 
-	return &Client{Client: client}, nil
+	if len(session.GetBalances()) > 0 {
+		client.SetBalances(ctx, &api.Balances{Data: session.GetBalances()})
+	}
+
+	log.Debug().Str("user", userID).Bool("replaced", replaced).Msg("new exchange session")
+
+	return client, nil
+}
+
+func (a *App) newClient(ctx context.Context, userID string, cfg *config.Config) (*exchange.Client, error) {
+	// manual clock is advanced by step requests only
+	parserConfig := cfg.Parser
+	if cfg.Exchange.Clock == exchange.ClockManual {
+		parserConfig.Step = true
+	}
+
+	listener, err := a.parser.NewListener(parserConfig)
+	if err != nil {
+		return nil, err
+	}
+	client := exchange.New(ctx, cfg, a.info, listener, logger.NewUser(userID))
+
+	log.Debug().Str("user", userID).Msg("new exchange client")
+	a.clients.Set(userID, client)
+	a.shutdown <- shutdownHandler{
+		shutdown: client.Shutdown(),
+		client:   client,
+		clientID: userID,
+	}
+
+	return client, nil
+}
+
+// sessionConfig overrides the config with values set by the session
+func sessionConfig(global *config.Config, session *api.SessionConfig) (*config.Config, error) {
+	cfg := *global
+	if len(session.GetSymbols()) > 0 {
+		cfg.Parser.Symbols = session.GetSymbols()
+	}
+	if session.GetStart() > 0 {
+		cfg.Parser.Offset = session.GetStart()
+	}
+	if session.GetEnd() > 0 {
+		cfg.Parser.End = session.GetEnd()
+	}
+	if cfg.Parser.End > 0 && cfg.Parser.End < cfg.Parser.Offset {
+		return nil, errors.Wrap(ErrInvalidSession, "end is before start")
+	}
+	if session.GetDelayUs() > 0 {
+		cfg.Parser.ListenerDelay = time.Duration(session.GetDelayUs()) * time.Microsecond
+	}
+	if session.GetStep() {
+		cfg.Parser.Step = true
+	}
+	if session.GetRealtime() {
+		cfg.Parser.Realtime = true
+	}
+	if session.GetClock() != "" {
+		if !exchange.ValidClock(session.GetClock()) {
+			return nil, errors.Wrapf(ErrInvalidSession, "clock %s", session.GetClock())
+		}
+		cfg.Exchange.Clock = session.GetClock()
+	}
+	if cfg.Parser.Realtime {
+		if cfg.Parser.Step || cfg.Exchange.Clock == exchange.ClockManual {
+			return nil, errors.Wrap(ErrInvalidSession, "realtime replay can't be stepped")
+		}
+		if session.GetClock() != "" && session.GetClock() != exchange.ClockAlways {
+			return nil, errors.Wrapf(ErrInvalidSession, "realtime replay can't run the %s clock", session.GetClock())
+		}
+		cfg.Exchange.Clock = exchange.ClockAlways
+	}
+	if session.GetSeed() != 0 {
+		cfg.Exchange.PricePathSeed = session.GetSeed()
+	}
+
+	var err error
+	if cfg.Exchange.MakerCommission, err = parseCommission(session.GetMakerCommission(), cfg.Exchange.MakerCommission); err != nil {
+		return nil, err
+	}
+	if cfg.Exchange.TakerCommission, err = parseCommission(session.GetTakerCommission(), cfg.Exchange.TakerCommission); err != nil {
+		return nil, err
+	}
+	if len(session.GetCommissions()) > 0 {
+		cfg.Exchange.Commissions = make([]config.CommissionConfig, len(session.GetCommissions()))
+		for i, cc := range session.GetCommissions() {
+			commission := &cfg.Exchange.Commissions[i]
+			commission.Symbol = cc.GetSymbol()
+			if commission.Maker, err = parseCommission(cc.GetMaker(), cfg.Exchange.MakerCommission); err != nil {
+				return nil, err
+			}
+			if commission.Taker, err = parseCommission(cc.GetTaker(), cfg.Exchange.TakerCommission); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &cfg, nil
+}
+
+// parseCommission parses a fee percentage, empty value keeps the current one
+func parseCommission(value string, current float64) (float64, error) {
+	if value == "" {
+		return current, nil
+	}
+	commission, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.Wrapf(ErrInvalidSession, "commission %s", value)
+	}
+	return commission, nil
 }
 
 type shutdownHandler struct {
 	shutdown <-chan struct{}
+	client   *exchange.Client
 	clientID string
 }
 
@@ -114,7 +270,10 @@ func (a *App) startShutdownHandler(ctx context.Context) {
 			for i := len(clients) - 1; i >= 0; i-- {
 				select {
 				case <-clients[i].shutdown:
-					a.clients.Del(clients[i].clientID)
+					// the client may be already replaced by a new session
+					if client, ok := a.clients.Get(clients[i].clientID); ok && client == clients[i].client {
+						a.clients.Del(clients[i].clientID)
+					}
 					log.Debug().Str("user", clients[i].clientID).Msg("client shutdown")
 					clients = append(clients[:i], clients[i+1:]...)
 				default:
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/gen/proto/api"
+	"github.com/xenking/exchange-emulator/internal/exchange"
+)
+
+func globalConfig() *config.Config {
+	return &config.Config{
+		Parser: config.ParserConfig{
+			ListenerDelay: 3 * time.Millisecond,
+			Offset:        100,
+			Symbols:       []string{"BTCUSDT"},
+		},
+		Exchange: config.ExchangeConfig{
+			MakerCommission: 0.1,
+			TakerCommission: 0.1,
+			Clock:           exchange.ClockOrders,
+		},
+	}
+}
+
+func TestSessionConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *api.SessionConfig
+		check   func(t *testing.T, cfg *config.Config)
+		err     error
+	}{
+		{
+			name:    "empty session keeps global",
+			session: &api.SessionConfig{},
+			check: func(t *testing.T, cfg *config.Config) {
+				if !reflect.DeepEqual(cfg, globalConfig()) {
+					t.Errorf("config %+v, want %+v", cfg, globalConfig())
+				}
+			},
+		},
+		{
+			name:    "replay window",
+			session: &api.SessionConfig{Symbols: []string{"ETHUSDT", "BNBUSDT"}, Start: 200, End: 300, DelayUs: 500},
+			check: func(t *testing.T, cfg *config.Config) {
+				if !reflect.DeepEqual(cfg.Parser.Symbols, []string{"ETHUSDT", "BNBUSDT"}) {
+					t.Errorf("symbols %v", cfg.Parser.Symbols)
+				}
+				if cfg.Parser.Offset != 200 || cfg.Parser.End != 300 {
+					t.Errorf("window %d-%d, want 200-300", cfg.Parser.Offset, cfg.Parser.End)
+				}
+				if cfg.Parser.ListenerDelay != 500*time.Microsecond {
+					t.Errorf("delay %s, want 500µs", cfg.Parser.ListenerDelay)
+				}
+			},
+		},
+		{
+			name:    "end before global start",
+			session: &api.SessionConfig{End: 50},
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "end before start",
+			session: &api.SessionConfig{Start: 300, End: 200},
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "clock",
+			session: &api.SessionConfig{Clock: exchange.ClockManual, Seed: 7},
+			check: func(t *testing.T, cfg *config.Config) {
+				if cfg.Exchange.Clock != exchange.ClockManual {
+					t.Errorf("clock %s, want %s", cfg.Exchange.Clock, exchange.ClockManual)
+				}
+				if cfg.Exchange.PricePathSeed != 7 {
+					t.Errorf("seed %d, want 7", cfg.Exchange.PricePathSeed)
+				}
+			},
+		},
+		{
+			name:    "invalid clock",
+			session: &api.SessionConfig{Clock: "sometimes"},
+			err:     ErrInvalidSession,
+		},
+		{
+			name: "commissions",
+			session: &api.SessionConfig{
+				MakerCommission: "-0.01",
+				Commissions: []*api.Commission{
+					{Symbol: "ETHUSDT", Taker: "0.05"},
+				},
+			},
+			check: func(t *testing.T, cfg *config.Config) {
+				if cfg.Exchange.MakerCommission != -0.01 || cfg.Exchange.TakerCommission != 0.1 {
+					t.Errorf("commissions %v/%v, want -0.01/0.1", cfg.Exchange.MakerCommission, cfg.Exchange.TakerCommission)
+				}
+				want := []config.CommissionConfig{{Symbol: "ETHUSDT", Maker: -0.01, Taker: 0.05}}
+				if !reflect.DeepEqual(cfg.Exchange.Commissions, want) {
+					t.Errorf("symbol commissions %+v, want %+v", cfg.Exchange.Commissions, want)
+				}
+			},
+		},
+		{
+			name:    "invalid commission",
+			session: &api.SessionConfig{Commissions: []*api.Commission{{Symbol: "ETHUSDT", Maker: "1%"}}},
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "realtime",
+			session: &api.SessionConfig{Realtime: true, Clock: exchange.ClockAlways},
+			check: func(t *testing.T, cfg *config.Config) {
+				if !cfg.Parser.Realtime {
+					t.Error("realtime is not set")
+				}
+			},
+		},
+		{
+			name:    "realtime global clock",
+			session: &api.SessionConfig{Realtime: true},
+			check: func(t *testing.T, cfg *config.Config) {
+				if cfg.Exchange.Clock != exchange.ClockAlways {
+					t.Errorf("clock %s, want %s", cfg.Exchange.Clock, exchange.ClockAlways)
+				}
+			},
+		},
+		{
+			name:    "realtime orders clock",
+			session: &api.SessionConfig{Realtime: true, Clock: exchange.ClockOrders},
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "realtime step",
+			session: &api.SessionConfig{Realtime: true, Step: true},
+			err:     ErrInvalidSession,
+		},
+		{
+			name:    "realtime manual clock",
+			session: &api.SessionConfig{Realtime: true, Clock: exchange.ClockManual},
+			err:     ErrInvalidSession,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			global := globalConfig()
+			cfg, err := sessionConfig(global, tt.session)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(global, globalConfig()) {
+				t.Errorf("global config is changed: %+v", global)
+			}
+			if tt.check != nil {
+				tt.check(t, cfg)
+			}
+		})
+	}
+}
+
+func TestNewRealtime(t *testing.T) {
+	tests := []struct {
+		name  string
+		step  bool
+		clock string
+	}{
+		{name: "step", step: true, clock: exchange.ClockOrders},
+		{name: "manual clock", clock: exchange.ClockManual},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := globalConfig()
+			cfg.Parser.Realtime = true
+			cfg.Parser.Step = tt.step
+			cfg.Exchange.Clock = tt.clock
+			if _, err := New(nil, nil, cfg); !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("New() error = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
@@ -2,10 +2,15 @@ package app
 
 import (
 	"context"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
 	"github.com/xenking/decimal"
 
 	"github.com/xenking/exchange-emulator/gen/proto/api"
 	"github.com/xenking/exchange-emulator/internal/balance"
+	"github.com/xenking/exchange-emulator/internal/book"
 	"github.com/xenking/exchange-emulator/internal/exchange"
 	"github.com/xenking/exchange-emulator/internal/order"
 	"github.com/xenking/exchange-emulator/internal/parser"
@@ -15,6 +20,30 @@ type Client struct {
 	*exchange.Client
 }
 
+func newAPIOrder(o *order.Order) *api.Order {
+	return &api.Order{
+		Id:                o.Id,
+		Symbol:            o.Symbol,
+		Side:              o.Side,
+		Type:              o.Type,
+		Price:             o.Order.Price,
+		Quantity:          o.Order.Quantity,
+		Total:             o.Order.Total,
+		Status:            o.Status,
+		OrderId:           o.OrderId,
+		UserId:            o.UserId,
+		TransactTime:      o.TransactTime,
+		ExecutedQuantity:  o.Order.ExecutedQuantity,
+		StopPrice:         o.Order.StopPrice,
+		OrderListId:       o.Order.OrderListId,
+		TimeInForce:       o.TimeInForce,
+		GoodTillTime:      o.GoodTillTime,
+		FillPrice:         o.Order.FillPrice,
+		TrailingDelta:     o.Order.TrailingDelta,
+		TrailingDeltaType: o.TrailingDeltaType,
+	}
+}
+
 func (c *Client) CreateOrder(ctx context.Context, userID string, apiOrder *api.Order) (*api.Order, error) {
 	c.Log.Trace().Str("type", "create order").Msg("grpc action")
 
@@ -25,26 +54,13 @@ func (c *Client) CreateOrder(ctx context.Context, userID string, apiOrder *api.O
 		resp *api.Order
 	)
 	c.NewAction(ctx, func(state parser.ExchangeState) {
-		o := c.Order.Add(apiOrder, state.Unix)
+		var o *order.Order
+		o, err = c.AddOrder(apiOrder, state)
 		if o == nil {
-			err = order.ErrNotFound
 			return
 		}
 
-		err = c.UpdateBalance(o)
-		resp = &api.Order{
-			Id:           o.Id,
-			Symbol:       o.Symbol,
-			Side:         o.Side,
-			Type:         o.Type,
-			Price:        o.Order.Price,
-			Quantity:     o.Order.Quantity,
-			Total:        o.Order.Total,
-			Status:       o.Status,
-			OrderId:      o.OrderId,
-			UserId:       o.UserId,
-			TransactTime: o.TransactTime,
-		}
+		resp = newAPIOrder(o)
 	})
 
 	return resp, err
@@ -62,37 +78,17 @@ func (c *Client) ReplaceOrder(ctx context.Context, userID string, cancelID strin
 	c.NewAction(ctx, func(state parser.ExchangeState) {
 		c.Log.Debug().Str("cancelID", cancelID).Msg("replacing order")
 
-		cancel := c.Order.Cancel(cancelID)
-		if cancel != nil {
-			err = c.UpdateBalance(cancel)
-			if err != nil {
-				return
-			}
-		} else {
-			err = order.ErrNotFound
+		if err = c.Client.CancelOrder(cancelID); err != nil {
 			return
 		}
 
-		o := c.Order.Add(apiOrder, state.Unix)
+		var o *order.Order
+		o, err = c.AddOrder(apiOrder, state)
 		if o == nil {
-			err = order.ErrNotFound
 			return
 		}
 
-		err = c.UpdateBalance(o)
-		resp = &api.Order{
-			Id:           o.Id,
-			Symbol:       o.Symbol,
-			Side:         o.Side,
-			Type:         o.Type,
-			Price:        o.Order.Price,
-			Quantity:     o.Order.Quantity,
-			Total:        o.Order.Total,
-			Status:       o.Status,
-			OrderId:      o.OrderId,
-			UserId:       o.UserId,
-			TransactTime: o.TransactTime,
-		}
+		resp = newAPIOrder(o)
 	})
 
 	return resp, err
@@ -104,31 +100,47 @@ func (c *Client) CreateOrders(ctx context.Context, userID string, apiOrders []*a
 	var err error
 	resp := make([]*api.Order, len(apiOrders))
 	c.NewAction(ctx, func(state parser.ExchangeState) {
-		unix := state.Unix
 		for i, apiOrder := range apiOrders {
 			apiOrder.UserId = userID
-			unix += 1 // add 10 ms time offset to prevent duplicate orders
+			state.Unix += 1 // add 10 ms time offset to prevent duplicate orders
 
-			o := c.Order.Add(apiOrder, unix)
+			var o *order.Order
+			o, err = c.AddOrder(apiOrder, state)
 			if o == nil {
-				err = order.ErrNotFound
 				return
 			}
 
-			err = c.UpdateBalance(o)
-			resp[i] = &api.Order{
-				Id:           o.Id,
-				Symbol:       o.Symbol,
-				Side:         o.Side,
-				Type:         o.Type,
-				Price:        o.Order.Price,
-				Quantity:     o.Order.Quantity,
-				Total:        o.Order.Total,
-				Status:       o.Status,
-				OrderId:      o.OrderId,
-				UserId:       o.UserId,
-				TransactTime: o.TransactTime,
-			}
+			resp[i] = newAPIOrder(o)
+		}
+	})
+
+	return resp, err
+}
+
+func (c *Client) CreateOrderList(ctx context.Context, userID string, list *api.OrderList) (*api.OrderList, error) {
+	c.Log.Trace().Str("type", "create order list").Msg("grpc action")
+
+	for _, apiOrder := range list.GetOrders() {
+		apiOrder.UserId = userID
+	}
+
+	var (
+		err  error
+		resp *api.OrderList
+	)
+	c.NewAction(ctx, func(state parser.ExchangeState) {
+		var orders []*order.Order
+		orders, err = c.AddOrderList(list, state)
+		if orders == nil {
+			return
+		}
+
+		resp = &api.OrderList{
+			Id:     orders[0].OrderListId,
+			Orders: make([]*api.Order, len(orders)),
+		}
+		for i, o := range orders {
+			resp.Orders[i] = newAPIOrder(o)
 		}
 	})
 
@@ -148,19 +160,7 @@ func (c *Client) GetOrder(ctx context.Context, orderID string) (*api.Order, erro
 			return
 		}
 
-		resp = &api.Order{
-			Id:           o.Id,
-			Symbol:       o.Symbol,
-			Side:         o.Side,
-			Type:         o.Type,
-			Price:        o.Order.Price,
-			Quantity:     o.Order.Quantity,
-			Total:        o.Order.Total,
-			Status:       o.Status,
-			OrderId:      o.OrderId,
-			UserId:       o.UserId,
-			TransactTime: o.TransactTime,
-		}
+		resp = newAPIOrder(o)
 	})
 
 	return resp, err
@@ -170,13 +170,7 @@ func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
 	c.Log.Trace().Str("type", "cancel order").Msg("grpc action")
 	var err error
 	c.NewAction(ctx, func(state parser.ExchangeState) {
-		o := c.Order.Cancel(orderID)
-		if o == nil {
-			err = order.ErrNotFound
-			return
-		}
-
-		err = c.UpdateBalance(o)
+		err = c.Client.CancelOrder(orderID)
 	})
 
 	return err
@@ -189,13 +183,9 @@ func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) error {
 		for _, orderID := range orderIDs {
 			c.Log.Debug().Str("id", orderID).Msg("cancelling order")
 
-			o := c.Order.Cancel(orderID)
-			if o == nil {
-				err = order.ErrNotFound
+			if err = c.Client.CancelOrder(orderID); err != nil {
 				return
 			}
-
-			err = c.UpdateBalance(o)
 		}
 	})
 
@@ -239,12 +229,114 @@ func (c *Client) SetBalances(ctx context.Context, balances *api.Balances) {
 
 func (c *Client) GetPrice(ctx context.Context, symbol string) *api.Price {
 	c.Log.Trace().Str("type", "get price").Msg("grpc action")
-	var price string
+	var (
+		price string
+		unix  int64
+	)
 	c.NewAction(ctx, func(state parser.ExchangeState) {
-		price = state.Close.String()
+		if s, ok := c.SymbolState(strings.ToUpper(symbol), state); ok {
+			price = s.Close.String()
+		}
+		unix = state.Unix
 	})
 
 	return &api.Price{
 		Price: price,
+		Unix:  unix,
+	}
+}
+
+func (c *Client) GetOrderBook(ctx context.Context, symbol string, limit int) (*api.OrderBook, error) {
+	c.Log.Trace().Str("type", "get order book").Msg("grpc action")
+
+	symbol = strings.ToUpper(symbol)
+
+	var (
+		err  error
+		resp *api.OrderBook
+	)
+	c.NewAction(ctx, func(state parser.ExchangeState) {
+		b, ok := c.OrderBook(symbol)
+		if !ok {
+			err = errors.Wrap(exchange.ErrNoOrderBook, symbol)
+			return
+		}
+
+		resp = &api.OrderBook{
+			Symbol: symbol,
+			Unix:   b.Unix() + c.Parser.Shift(),
+			Bids:   newPriceLevels(b.Levels(true, limit)),
+			Asks:   newPriceLevels(b.Levels(false, limit)),
+		}
+	})
+
+	return resp, err
+}
+
+func newPriceLevels(levels []book.Level) []*api.PriceLevel {
+	resp := make([]*api.PriceLevel, len(levels))
+	for i, l := range levels {
+		resp[i] = &api.PriceLevel{
+			Price:    l.Price.String(),
+			Quantity: l.Quantity.String(),
+		}
+	}
+	return resp
+}
+
+func (c *Client) Pause(ctx context.Context) *api.Playback {
+	c.Log.Trace().Str("type", "pause").Msg("grpc action")
+	return newAPIPlayback(c.Parser.Pause())
+}
+
+func (c *Client) Resume(ctx context.Context) *api.Playback {
+	c.Log.Trace().Str("type", "resume").Msg("grpc action")
+	return newAPIPlayback(c.Parser.Resume())
+}
+
+func (c *Client) SeekTime(ctx context.Context, unix int64) (*api.Playback, error) {
+	c.Log.Trace().Str("type", "seek").Int64("unix", unix).Msg("grpc action")
+	playback, err := c.Parser.SeekTime(unix)
+	return newAPIPlayback(playback), err
+}
+
+func (c *Client) SetDelay(ctx context.Context, delay time.Duration) (*api.Playback, error) {
+	c.Log.Trace().Str("type", "set delay").Dur("delay", delay).Msg("grpc action")
+	playback, err := c.Parser.SetDelay(delay)
+	return newAPIPlayback(playback), err
+}
+
+// Advance replays n timestamps in step mode, zero n acknowledges the last kline and replays the next one
+func (c *Client) Advance(ctx context.Context, n int) (*api.Playback, error) {
+	c.Log.Trace().Str("type", "advance").Int("n", n).Msg("grpc action")
+	if n == 0 {
+		n = 1
+	}
+	err := c.Client.Advance(ctx, n)
+	return newAPIPlayback(c.Parser.Playback()), err
+}
+
+func (c *Client) GetClock(ctx context.Context) *api.Clock {
+	c.Log.Trace().Str("type", "get clock").Msg("grpc action")
+	policy, running, unix := c.Clock(ctx)
+	return &api.Clock{
+		Policy:       policy,
+		Running:      running,
+		Unix:         unix,
+		ActiveOrders: int32(c.Order.Active()),
+	}
+}
+
+func (c *Client) Playback(ctx context.Context) *api.Playback {
+	return newAPIPlayback(c.Parser.Playback())
+}
+
+func newAPIPlayback(p parser.Playback) *api.Playback {
+	return &api.Playback{
+		Paused:   p.Paused,
+		Step:     p.Step,
+		Realtime: p.Realtime,
+		Unix:     p.Unix,
+		DelayUs:  p.Delay.Microseconds(),
 	}
 }
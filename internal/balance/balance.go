@@ -104,6 +104,7 @@ func (t *Tracker) Set(balances []Asset) {
 		transactionType: typeSet,
 		action: func(_ *Asset) {
 			for _, balance := range balances {
+				balance := balance
 				t.data[balance.Name] = &balance
 				t.log.Trace().Str("asset", balance.Name).Str("free", balance.Free.String()).
 					Str("locked", balance.Locked.String()).Msg("balance set")
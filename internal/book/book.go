@@ -0,0 +1,182 @@
+package book
+
+import (
+	"sort"
+
+	"github.com/xenking/decimal"
+)
+
+type Level struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+// Update sets quantity of the price level, zero quantity removes the level.
+// Snapshot updates following diff ones replace the whole book.
+type Update struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+	Unix     int64
+	Bid      bool
+	Snapshot bool
+}
+
+// Updates is a read-only history of the symbol book shared by sessions
+type Updates struct {
+	Symbol string
+	data   []Update
+}
+
+// Book replays updates of the symbol book. Bids are ordered by price descending, asks ascending.
+type Book struct {
+	updates  []Update
+	bids     []Level
+	asks     []Level
+	next     int
+	unix     int64
+	snapshot bool
+}
+
+func New(updates *Updates) *Book {
+	return &Book{
+		updates: updates.data,
+	}
+}
+
+// Unix returns time of the last applied update
+func (b *Book) Unix() int64 {
+	return b.unix
+}
+
+// Advance applies updates up to the timestamp
+func (b *Book) Advance(unix int64) {
+	for ; b.next < len(b.updates) && b.updates[b.next].Unix <= unix; b.next++ {
+		u := &b.updates[b.next]
+		if u.Snapshot && !b.snapshot {
+			b.bids, b.asks = b.bids[:0], b.asks[:0]
+		}
+		b.snapshot = u.Snapshot
+		b.unix = u.Unix
+
+		if u.Bid {
+			b.bids = setLevel(b.bids, u.Price, u.Quantity, true)
+		} else {
+			b.asks = setLevel(b.asks, u.Price, u.Quantity, false)
+		}
+	}
+}
+
+// Reset clears the book to replay updates from the start
+func (b *Book) Reset() {
+	b.bids, b.asks = b.bids[:0], b.asks[:0]
+	b.next, b.unix, b.snapshot = 0, 0, false
+}
+
+// Levels returns up to limit best levels of the side, zero limit returns all levels
+func (b *Book) Levels(bid bool, limit int) []Level {
+	levels := b.asks
+	if bid {
+		levels = b.bids
+	}
+	if limit > 0 && limit < len(levels) {
+		levels = levels[:limit]
+	}
+	return levels
+}
+
+// Best returns the best level of the side, false if the side is empty
+func (b *Book) Best(bid bool) (Level, bool) {
+	levels := b.Levels(bid, 1)
+	if len(levels) == 0 {
+		return Level{}, false
+	}
+	return levels[0], true
+}
+
+// Available returns a quantity an order can take from the book up to the limit price, zero limit takes any price
+func (b *Book) Available(buy bool, limit decimal.Decimal) decimal.Decimal {
+	available := decimal.Zero
+	for _, l := range b.opposite(buy) {
+		if !crosses(buy, l.Price, limit) {
+			break
+		}
+		available = available.Add(l.Quantity)
+	}
+	return available
+}
+
+// Take walks levels of the opposite side up to the limit price and removes consumed liquidity.
+// It returns the filled quantity and its quote total, zero limit takes any price.
+func (b *Book) Take(buy bool, qty, limit decimal.Decimal) (filled, total decimal.Decimal) {
+	levels := b.opposite(buy)
+
+	taken := 0
+	for i := range levels {
+		l := &levels[i]
+		if qty.IsZero() || !crosses(buy, l.Price, limit) {
+			break
+		}
+
+		q := decimal.Min(qty, l.Quantity)
+		filled = filled.Add(q)
+		total = total.Add(q.Mul(l.Price))
+		qty = qty.Sub(q)
+		l.Quantity = l.Quantity.Sub(q)
+		if l.Quantity.IsZero() {
+			taken++
+		}
+	}
+
+	if buy {
+		b.asks = b.asks[taken:]
+	} else {
+		b.bids = b.bids[taken:]
+	}
+
+	return filled, total
+}
+
+func (b *Book) opposite(buy bool) []Level {
+	if buy {
+		return b.asks
+	}
+	return b.bids
+}
+
+func crosses(buy bool, price, limit decimal.Decimal) bool {
+	switch {
+	case limit.IsZero():
+		return true
+	case buy:
+		return price.LessThanOrEqual(limit)
+	default:
+		return price.GreaterThanOrEqual(limit)
+	}
+}
+
+// setLevel sets quantity of the level keeping bids descending and asks ascending
+func setLevel(levels []Level, price, qty decimal.Decimal, bid bool) []Level {
+	i := sort.Search(len(levels), func(i int) bool {
+		if bid {
+			return levels[i].Price.LessThanOrEqual(price)
+		}
+		return levels[i].Price.GreaterThanOrEqual(price)
+	})
+
+	found := i < len(levels) && levels[i].Price.Equal(price)
+	switch {
+	case found && qty.IsZero():
+		return append(levels[:i], levels[i+1:]...)
+	case found:
+		levels[i].Quantity = qty
+		return levels
+	case qty.IsZero():
+		return levels
+	}
+
+	levels = append(levels, Level{})
+	copy(levels[i+1:], levels[i:])
+	levels[i] = Level{Price: price, Quantity: qty}
+
+	return levels
+}
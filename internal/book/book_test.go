@@ -0,0 +1,53 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xenking/decimal"
+)
+
+const testUpdates = `exchange,symbol,timestamp,local_timestamp,is_snapshot,side,price,amount
+binance,ETHUSDT,1640995200000000,1640995200001000,true,ask,3676.23,1.5
+binance,ETHUSDT,1640995200000000,1640995200001000,true,ask,3676.50,2
+binance,ETHUSDT,1640995200000000,1640995200001000,true,bid,3676.22,3
+binance,ETHUSDT,1640995200000000,1640995200001000,true,bid,3676.00,1
+binance,ETHUSDT,1640995230000000,1640995230001000,false,ask,3676.40,0.5
+binance,ETHUSDT,1640995230000000,1640995230001000,false,bid,3676.00,0
+binance,ETHUSDT,1640995290000000,1640995290001000,false,ask,3677.00,4
+`
+
+func TestBookReplay(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "book.csv")
+	if err := os.WriteFile(file, []byte(testUpdates), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	updates, err := Load(file, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updates.Symbol != "ETHUSDT" {
+		t.Fatalf("symbol = %s, want ETHUSDT", updates.Symbol)
+	}
+
+	b := New(updates)
+	b.Advance(1640995260000)
+	if asks, bids := b.Levels(false, 0), b.Levels(true, 0); len(asks) != 3 || len(bids) != 1 {
+		t.Fatalf("book has %d asks and %d bids, want 3 and 1", len(asks), len(bids))
+	}
+
+	limit := decimal.RequireFromString("3676.45")
+	if available := b.Available(true, limit); available.String() != "2" {
+		t.Errorf("Available() = %s, want 2", available)
+	}
+
+	qty, total := b.Take(true, decimal.RequireFromString("2.5"), decimal.Zero)
+	if qty.String() != "2.5" || total.String() != "9190.795" {
+		t.Errorf("Take() = %s for %s, want 2.5 for 9190.795", qty, total)
+	}
+	if best := b.Levels(false, 1); len(best) != 1 || best[0].Price.String() != "3676.5" || best[0].Quantity.String() != "1.5" {
+		t.Errorf("best ask after take = %+v", best)
+	}
+}
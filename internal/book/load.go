@@ -0,0 +1,103 @@
+package book
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/pkg/utils"
+)
+
+// L2 update csv columns with header, as in incremental_book_L2 exports:
+// exchange, symbol, timestamp (us), local timestamp, is snapshot, side (bid or ask), price, amount
+const (
+	columnSymbol    = 1
+	columnTimestamp = 2
+	columnSnapshot  = 4
+	columnSide      = 5
+	columnPrice     = 6
+	columnAmount    = 7
+	columns         = 8
+)
+
+var ErrInvalidUpdate = errors.New("invalid order book update")
+
+// Load reads L2 updates of the file ordered by time. Symbol is taken from the file if it's empty.
+func Load(file, symbol string) (*Updates, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	u := &Updates{Symbol: symbol}
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		row := bytes.TrimRight(sc.Bytes(), "\r")
+		if line == 1 || len(row) == 0 {
+			continue
+		}
+
+		fields := bytes.Split(row, []byte{','})
+		if len(fields) < columns {
+			return nil, errors.Wrapf(ErrInvalidUpdate, "line %d", line)
+		}
+		if u.Symbol == "" {
+			u.Symbol = strings.NewReplacer("-", "", "/", "").Replace(strings.ToUpper(string(fields[columnSymbol])))
+		}
+
+		update, err := parseUpdate(fields)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		u.data = append(u.data, update)
+	}
+	if err = sc.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(u.data, func(i, j int) bool {
+		return u.data[i].Unix < u.data[j].Unix
+	})
+
+	return u, nil
+}
+
+func parseUpdate(fields [][]byte) (Update, error) {
+	var (
+		u   Update
+		err error
+	)
+
+	u.Unix, err = utils.ParseUintBytes(fields[columnTimestamp])
+	if err != nil {
+		return u, errors.Wrap(ErrInvalidUpdate, err.Error())
+	}
+	// microseconds to milliseconds of klines
+	u.Unix /= 1000
+
+	u.Snapshot = string(fields[columnSnapshot]) == "true"
+	switch string(fields[columnSide]) {
+	case "bid", "buy":
+		u.Bid = true
+	case "ask", "sell":
+	default:
+		return u, errors.Wrapf(ErrInvalidUpdate, "side %s", fields[columnSide])
+	}
+
+	if u.Price, err = decimal.NewFromString(string(fields[columnPrice])); err != nil {
+		return u, errors.Wrap(ErrInvalidUpdate, err.Error())
+	}
+	if u.Quantity, err = decimal.NewFromString(string(fields[columnAmount])); err != nil {
+		return u, errors.Wrap(ErrInvalidUpdate, err.Error())
+	}
+
+	return u, nil
+}
@@ -2,15 +2,20 @@ package exchange
 
 import (
 	"context"
+	"math/rand"
+	"strings"
 	"sync/atomic"
+	"time"
 
+	"github.com/go-faster/errors"
 	"github.com/phuslu/log"
-	"github.com/xenking/bytebufferpool"
 	"github.com/xenking/decimal"
 
 	"github.com/xenking/exchange-emulator/config"
 	"github.com/xenking/exchange-emulator/gen/proto/api"
 	"github.com/xenking/exchange-emulator/internal/balance"
+	"github.com/xenking/exchange-emulator/internal/book"
+	"github.com/xenking/exchange-emulator/internal/info"
 	"github.com/xenking/exchange-emulator/internal/order"
 	"github.com/xenking/exchange-emulator/internal/parser"
 	"github.com/xenking/exchange-emulator/internal/ws"
@@ -21,19 +26,36 @@ type Client struct {
 	Balance       *balance.Tracker
 	Order         *order.Tracker
 	Log           *log.Logger
+	info          *info.Info
 	orderConn     *ws.UserConn
 	priceConn     *ws.UserConn
 	actions       chan Action
+	steps         chan stepRequest
 	shutdown      chan struct{}
 	cancel        context.CancelFunc
 	cancelHandler func(state parser.ExchangeState)
-	commission    decimal.Decimal
+	commission    commission
+	commissions   map[string]commission
+	marketBuffer  decimal.Decimal
+	participation decimal.Decimal
+	rnd           *rand.Rand
+	matches       []match
+	states        map[string]parser.ExchangeState
+	books         map[string]*book.Book
+	prices        *parser.PriceEncoder
+	resamplers    []*parser.Resampler
+	interval      int64
+	realtime      bool
+	marketPrice   marketPrice
+	pathModel     pathModel
+	clock         string
+	running       bool
 	closed        int32
 }
 
 type Action func(parser.ExchangeState)
 
-func New(parentCtx context.Context, config *config.Config, listener *parser.Listener, logger *log.Logger) *Client {
+func New(parentCtx context.Context, config *config.Config, exchangeInfo *info.Info, listener *parser.Listener, logger *log.Logger) *Client {
 	ctx, cancel := context.WithCancel(parentCtx)
 
 	go listener.Start(ctx)
@@ -46,18 +68,60 @@ func New(parentCtx context.Context, config *config.Config, listener *parser.List
 	o.SetLogger(logger)
 	go o.Start(ctx)
 
-	commission := decimal.NewFromFloat(config.Exchange.Commission).Shift(-2)
-	commission = one.Sub(commission)
+	commissions := make(map[string]commission, len(config.Exchange.Commissions))
+	for _, cc := range config.Exchange.Commissions {
+		commissions[strings.ToUpper(cc.Symbol)] = newCommission(cc.Maker, cc.Taker)
+	}
+
+	mp, ok := marketPrices[config.Exchange.MarketPrice]
+	if !ok {
+		logger.Warn().Str("market_price", config.Exchange.MarketPrice).Msg("unknown market price, using close")
+	}
+
+	books := make(map[string]*book.Book, len(listener.Books()))
+	for symbol, updates := range listener.Books() {
+		books[symbol] = book.New(updates)
+	}
+
+	pm, ok := pathModels[config.Exchange.PricePath]
+	if !ok {
+		logger.Warn().Str("price_path", config.Exchange.PricePath).Msg("unknown price path, using ohlc")
+	}
+
+	clock := config.Exchange.Clock
+	if !ValidClock(clock) {
+		logger.Warn().Str("clock", clock).Msg("unknown clock policy, using orders")
+		clock = ClockOrders
+	}
+	// realtime states are sent at the wall time, a stopped clock would replay them late
+	if listener.Realtime() && clock != ClockAlways {
+		logger.Warn().Str("clock", clock).Msg("realtime replay can't be stopped, using always")
+		clock = ClockAlways
+	}
 
 	ex := &Client{
-		Parser:     listener,
-		Balance:    b,
-		Order:      o,
-		Log:        logger,
-		actions:    make(chan Action, 1024),
-		shutdown:   make(chan struct{}),
-		cancel:     cancel,
-		commission: commission,
+		Parser:        listener,
+		Balance:       b,
+		Order:         o,
+		Log:           logger,
+		info:          exchangeInfo,
+		actions:       make(chan Action, 1024),
+		steps:         make(chan stepRequest),
+		shutdown:      make(chan struct{}),
+		cancel:        cancel,
+		commission:    newCommission(config.Exchange.MakerCommission, config.Exchange.TakerCommission),
+		commissions:   commissions,
+		marketBuffer:  one.Add(decimal.NewFromFloat(config.Exchange.MarketBuffer).Shift(-2)),
+		participation: decimal.NewFromFloat(config.Exchange.ParticipationRate).Shift(-2),
+		marketPrice:   mp,
+		pathModel:     pm,
+		states:        make(map[string]parser.ExchangeState),
+		books:         books,
+		prices:        parser.NewPriceEncoder(listener.MultiSymbol()),
+		interval:      listener.Interval(),
+		realtime:      listener.Realtime(),
+		rnd:           rand.New(rand.NewSource(config.Exchange.PricePathSeed)), //nolint:gosec
+		clock:         clock,
 	}
 
 	go ex.Start(ctx)
@@ -78,25 +142,36 @@ func (c *Client) Start(ctx context.Context) {
 		c.Log.Warn().Msg("exchange closed")
 		return
 	}
+	c.setState(c.stamp(state))
 
 	var currentStates <-chan parser.ExchangeState
 	var deletedOrders []string
 	var lastState parser.ExchangeState
 
+	c.running = c.clockRunning()
+	if c.running {
+		currentStates = states
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-c.Order.Control():
-			if currentStates != nil {
-				c.Log.Debug().Msg("stop exchange")
-				currentStates = nil
-			} else {
+			running := c.clockRunning()
+			if running == c.running {
+				continue
+			}
+			c.running = running
+			if running {
 				c.Log.Debug().Msg("start exchange")
 				currentStates = states
+			} else {
+				c.Log.Debug().Msg("stop exchange")
+				currentStates = nil
 			}
 		case act := <-c.actions:
-			state.Unix += 1 // add 1 ms time offset to prevent duplicate orders
+			state.Unix = c.actionTime(state.Unix)
 			c.Log.Trace().Int64("ts", state.Unix).Msg("exchange action")
 			act(state)
 
@@ -110,13 +185,27 @@ func (c *Client) Start(ctx context.Context) {
 						c.Log.Warn().Msg("actions closed")
 						break
 					}
-					state.Unix += 1 // add 10 ms time offset to prevent duplicate orders
+					state.Unix = c.actionTime(state.Unix)
 					c.Log.Trace().Int64("ts", state.Unix).Msg("exchange action")
 					act(state)
 				default:
 					nextAction = false
 				}
 			}
+		case req := <-c.steps:
+			var err error
+			state, opened, err = c.step(ctx, states, req, state)
+			if opened {
+				lastState = state
+			}
+			req.done <- err
+			if !opened {
+				c.Log.Warn().Msg("exchange closed")
+				if c.cancelHandler != nil {
+					c.cancelHandler(lastState)
+				}
+				return
+			}
 		case state, opened = <-currentStates:
 			if !opened {
 				c.Log.Warn().Msg("exchange closed")
@@ -127,76 +216,179 @@ func (c *Client) Start(ctx context.Context) {
 				return
 			}
 			lastState = state
+			deletedOrders = c.processState(state, deletedOrders[:0])
+		}
+	}
+}
 
-			c.Log.Trace().Int64("ts", state.Unix).Msg("exchange state")
+// Clock policies
+const (
+	ClockAlways = "always"
+	ClockOrders = "orders"
+	ClockManual = "manual"
+)
 
-			if err := c.priceConn.Send(state.Raw); err != nil {
-				c.Log.Error().Err(err).Str("user", c.priceConn.ID).Msg("can't send price state")
-				continue
-			}
+func ValidClock(clock string) bool {
+	return clock == ClockAlways || clock == ClockOrders || clock == ClockManual
+}
 
-			deletedOrders = deletedOrders[:0]
-
-			c.Order.Range(func(orders []*order.Order) {
-				for _, o := range orders {
-					// buy
-					// price=10, high=12, low=9 -> bought
-					// price=10, high=15, low=11 -> continue
-					// price=10, high=9, low=8 -> bought
-					// price >= low
-					// sell
-					// price=10, high=12, low=9 -> sold
-					// price=10, high=15, low=11 -> sold
-					// price=10, high=9, low=8 -> continue
-					// price <= high
-					if o.Side == api.OrderSide_BUY && o.Price.LessThan(state.Low) ||
-						o.Side == api.OrderSide_SELL && o.Price.GreaterThan(state.High) {
-						continue
-					}
-					// TODO: skip for now because it's never happens before
-					//if o.Total.GreaterThan(state.AssetVolume) {
-					//	c.Log.Panic().Str("side", o.Side.String()).Str("total", o.Total.String()).
-					//		Str("asset", state.AssetVolume.String()).
-					//		Int64("ts", state.Unix).Msg("can't close order in one kline. Need to use PARTIAL_FILLED")
-					//}
-
-					o.Status = api.OrderStatus_FILLED
-
-					c.Log.Debug().Str("order", o.Id).Uint64("internal", o.OrderId).Str("user", o.UserId).Str("symbol", o.Symbol).
-						Str("side", o.Side.String()).Str("price", o.Order.Price).Str("qty", o.Order.Quantity).Int64("ts", state.Unix).
-						Msg("order filled")
-
-					if err := c.UpdateBalance(o); err != nil {
-						c.Log.Error().Err(err).Str("user", o.UserId).Str("order", o.Id).Msg("can't update balance")
-						continue
-					}
+// clockRunning reports whether the clock runs by its policy
+func (c *Client) clockRunning() bool {
+	switch c.clock {
+	case ClockAlways:
+		return true
+	case ClockManual:
+		return false
+	default:
+		return c.Order.Active() > 0
+	}
+}
 
-					deletedOrders = append(deletedOrders, o.Id)
+// Clock returns the clock policy, whether it's running and the exchange time
+func (c *Client) Clock(ctx context.Context) (policy string, running bool, unix int64) {
+	c.NewAction(ctx, func(state parser.ExchangeState) {
+		policy, running, unix = c.clock, c.running, state.Unix
+	})
+	return policy, running, unix
+}
 
-					buf := bytebufferpool.GetLen(29)
-					buf.B = buf.B[:0]
-					buf.B = o.AppendEncoded(buf.B)
-					err := c.orderConn.Send(buf.B)
-					bytebufferpool.Put(buf)
-					if err != nil {
-						c.Log.Error().Err(err).Str("user", c.orderConn.ID).Str("order", o.Id).Msg("can't send order update")
-						continue
-					}
-				}
-			})
+// actionTime returns a time of the action after the last one. Actions are 1 ms apart to prevent duplicate orders,
+// realtime actions happen at the wall time.
+func (c *Client) actionTime(last int64) int64 {
+	if c.realtime {
+		if now := time.Now().UnixMilli(); now > last {
+			return now
+		}
+	}
+	return last + 1
+}
 
-			switch len(deletedOrders) {
-			case 0:
-				continue
-			case 1:
-				c.Order.Cancel(deletedOrders[0])
-			default:
-				c.Order.RemoveRange(deletedOrders)
+// stamp returns the realtime kline at its close time: it's sent when it closes, so prices, fills and WS frames
+// follow the wall time. Replayed states and trades keep their time.
+func (c *Client) stamp(state parser.ExchangeState) parser.ExchangeState {
+	if c.realtime && c.interval > 0 {
+		state.Unix += c.interval - 1
+	}
+	return state
+}
+
+// processState matches orders on the state and sends its price to the prices WS.
+// Resampled klines are built on the kline open time.
+func (c *Client) processState(state parser.ExchangeState, deletedOrders []string) []string {
+	kline := state
+	state = c.stamp(state)
+	c.setState(state)
+
+	c.Log.Trace().Int64("ts", state.Unix).Msg("exchange state")
+
+	if price, ok := c.prices.Encode(state); ok {
+		if err := c.priceConn.Send(price); err != nil {
+			c.Log.Error().Err(err).Str("user", c.priceConn.ID).Msg("can't send price state")
+			return deletedOrders
+		}
+	}
+	for _, r := range c.resamplers {
+		r.Add(kline, c.sendKline)
+	}
+
+	deletedOrders = c.matchOrders(state, deletedOrders)
+	if len(deletedOrders) > 0 {
+		c.Order.RemoveRange(deletedOrders)
+	}
+
+	return deletedOrders
+}
+
+// stepRequest asks the exchange loop to replay n timestamps, the loop stops waiting for them when ctx is done
+type stepRequest struct {
+	ctx  context.Context
+	n    int
+	done chan error
+}
+
+// Advance replays n timestamps in step mode. It returns after states of the timestamps are matched
+// and sent to WS connections.
+func (c *Client) Advance(ctx context.Context, n int) error {
+	if c.IsClosed() {
+		return ErrReplayEnded
+	}
+
+	req := stepRequest{ctx: ctx, n: n, done: make(chan error, 1)}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.shutdown:
+		return ErrReplayEnded
+	case c.steps <- req:
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-req.done:
+		return err
+	}
+}
+
+// step processes states of the listener step, it returns the last state and false if the replay is ended.
+// It stops waiting for the step when the request is canceled, so the exchange keeps serving actions.
+func (c *Client) step(ctx context.Context, states <-chan parser.ExchangeState, req stepRequest, last parser.ExchangeState,
+) (parser.ExchangeState, bool, error) {
+	done, err := c.Parser.Step(req.n)
+	if err != nil {
+		return last, true, err
+	}
+
+	var deletedOrders []string
+	for {
+		select {
+		case <-ctx.Done():
+			return last, true, ctx.Err()
+		case <-req.ctx.Done():
+			return last, true, req.ctx.Err()
+		case <-done:
+			return last, true, nil
+		case state, ok := <-states:
+			if !ok {
+				return last, false, ErrReplayEnded
 			}
+			last = state
+			deletedOrders = c.processState(state, deletedOrders[:0])
 		}
 	}
 }
 
+// setState keeps the last state of the symbol and advances its order book.
+// The book is replayed from the start when the state goes back in time after seek.
+func (c *Client) setState(state parser.ExchangeState) {
+	if b, ok := c.books[state.Symbol]; ok {
+		if last, ok := c.states[state.Symbol]; ok && state.Unix < last.Unix {
+			b.Reset()
+		}
+		b.Advance(state.Unix - c.Parser.Shift())
+	}
+	c.states[state.Symbol] = state
+}
+
+// OrderBook returns the order book of the symbol at the current time
+func (c *Client) OrderBook(symbol string) (*book.Book, bool) {
+	b, ok := c.books[symbol]
+	return b, ok
+}
+
+// SymbolState returns the last state of the symbol with the action time.
+// States of datasets without symbol are used for any symbol.
+func (c *Client) SymbolState(symbol string, state parser.ExchangeState) (parser.ExchangeState, bool) {
+	s, ok := c.states[symbol]
+	if !ok {
+		s, ok = c.states[""]
+	}
+	if ok {
+		s.Unix = state.Unix
+	}
+	return s, ok
+}
+
 func (c *Client) SetOrdersConnection(conn *ws.UserConn) {
 	c.actions <- func(state parser.ExchangeState) {
 		if c.orderConn != nil {
@@ -215,10 +407,51 @@ func (c *Client) SetPricesConnection(conn *ws.UserConn) {
 		}
 
 		c.priceConn = conn
+		c.resamplers = c.newResamplers(conn)
 		go c.listenWSClose(conn)
 	}
 }
 
+// newResamplers returns resamplers of intervals requested by the connection.
+// Intervals must be multiples of the base interval of klines.
+func (c *Client) newResamplers(conn *ws.UserConn) []*parser.Resampler {
+	resamplers := make([]*parser.Resampler, 0, len(conn.Intervals))
+	for _, name := range conn.Intervals {
+		interval, err := parser.ParseInterval(name)
+		if err == nil && c.interval > 0 && interval%c.interval != 0 {
+			err = errors.Wrapf(parser.ErrInvalidInterval, "%s isn't a multiple of the base interval", name)
+		}
+		if err != nil {
+			c.Log.Warn().Err(err).Str("user", conn.ID).Msg("skip interval")
+			conn.SendError(err)
+			continue
+		}
+		resamplers = append(resamplers, parser.NewResampler(name, interval, c.interval))
+	}
+	return resamplers
+}
+
+// sendKline sends the resampled kline frame to the prices WS
+func (c *Client) sendKline(kline parser.ExchangeState) {
+	if err := c.priceConn.Send(c.prices.EncodeKline(kline)); err != nil {
+		c.Log.Error().Err(err).Str("user", c.priceConn.ID).Msg("can't send kline")
+	}
+}
+
+// Detach takes WS connections away from the client, so they stay open when it's closed
+func (c *Client) Detach(ctx context.Context) (orders, prices *ws.UserConn) {
+	if c.IsClosed() {
+		return nil, nil
+	}
+
+	c.NewAction(ctx, func(state parser.ExchangeState) {
+		orders, prices = c.orderConn, c.priceConn
+		c.orderConn, c.priceConn = nil, nil
+	})
+
+	return orders, prices
+}
+
 func (c *Client) SetCancelHandler(handler func(state parser.ExchangeState)) {
 	c.actions <- func(state parser.ExchangeState) {
 		c.cancelHandler = handler
@@ -250,61 +483,314 @@ func (c *Client) NewAction(ctx context.Context, action Action) {
 
 var one = decimal.NewFromInt(1)
 
+var (
+	ErrInvalidGoodTillTime = errors.New("good till time is in the past")
+	ErrWouldMatch          = errors.New("order would immediately match and take")
+	ErrInvalidTrailing     = errors.New("trailing delta must be positive and less than price")
+	ErrNoMarketData        = errors.New("no market data for symbol")
+	ErrNoOrderBook         = errors.New("no order book for symbol")
+	ErrReplayEnded         = errors.New("replay is ended")
+)
+
+// AddOrder adds a new order to the tracker and locks its balance.
+// Market orders lock an estimated cost based on the order symbol state close price,
+// stop market orders based on the stop price.
+func (c *Client) AddOrder(apiOrder *api.Order, state parser.ExchangeState) (*order.Order, error) {
+	o := c.Order.Add(apiOrder, state.Unix)
+	if o == nil {
+		return nil, order.ErrNotFound
+	}
+
+	state, ok := c.SymbolState(o.Symbol, state)
+	if !ok {
+		c.reject(o)
+		return o, errors.Wrap(ErrNoMarketData, o.Symbol)
+	}
+
+	if err := c.validate(o, state); err != nil {
+		c.reject(o)
+		return o, err
+	}
+
+	c.lock(o, state)
+
+	if err := c.UpdateBalance(o); err != nil {
+		c.reject(o)
+		return o, err
+	}
+
+	if (o.TimeInForce == api.TimeInForce_IOC || o.TimeInForce == api.TimeInForce_FOK) && !o.IsConditional() {
+		c.fillImmediate(o, state)
+	}
+
+	return o, nil
+}
+
+// AddOrderList adds one-cancels-the-other orders and locks balance once for the list.
+// The lock is the largest one of its orders, it's held by the first order and moves to the filled one.
+func (c *Client) AddOrderList(list *api.OrderList, state parser.ExchangeState) ([]*order.Order, error) {
+	orders, err := c.Order.AddList(list.GetId(), list.GetOrders(), state.Unix)
+	if err != nil {
+		return nil, err
+	}
+
+	state, ok := c.SymbolState(orders[0].Symbol, state)
+	if !ok {
+		for _, o := range orders {
+			c.reject(o)
+		}
+		return orders, errors.Wrap(ErrNoMarketData, orders[0].Symbol)
+	}
+
+	for _, o := range orders {
+		if err = c.validate(o, state); err != nil {
+			for _, o := range orders {
+				c.reject(o)
+			}
+			return orders, err
+		}
+	}
+
+	holder := orders[0]
+	for _, o := range orders {
+		c.lock(o, state)
+		if o != holder {
+			holder.Locked = decimal.Max(holder.Locked, o.Locked)
+			o.Locked = decimal.Zero
+		}
+	}
+
+	if err = c.UpdateBalance(holder); err != nil {
+		for _, o := range orders {
+			c.reject(o)
+		}
+		return orders, err
+	}
+
+	return orders, nil
+}
+
+// CancelOrder cancels the order with other orders of its list and releases their locked balance
+func (c *Client) CancelOrder(id string) error {
+	o := c.Order.Cancel(id)
+	if o == nil {
+		return order.ErrNotFound
+	}
+
+	canceled := []*order.Order{o}
+	if o.OrderListId != "" {
+		var ids []string
+		c.Order.Range(func(orders []*order.Order) {
+			for _, lo := range orders {
+				if lo.OrderListId == o.OrderListId {
+					ids = append(ids, lo.Id)
+				}
+			}
+		})
+		for _, id := range ids {
+			if lo := c.Order.Cancel(id); lo != nil {
+				canceled = append(canceled, lo)
+			}
+		}
+	}
+
+	var err error
+	for i, o := range canceled {
+		if uerr := c.UpdateBalance(o); uerr != nil {
+			err = uerr
+		}
+		// other orders of the list are canceled implicitly
+		if i > 0 {
+			c.Log.Debug().Str("order", o.Id).Str("list", o.OrderListId).Str("canceled", id).Msg("order list canceled")
+			c.sendOrder(o)
+		}
+	}
+
+	return err
+}
+
+// validate checks the order can be placed on the state and marks it as taker if it crosses the state price
+func (c *Client) validate(o *order.Order, state parser.ExchangeState) (err error) {
+	o.BaseAsset, o.QuoteAsset, err = c.info.Assets(o.Symbol)
+	if err != nil {
+		return err
+	}
+
+	if o.TimeInForce == api.TimeInForce_GTD && o.GoodTillTime <= state.Unix {
+		return ErrInvalidGoodTillTime
+	}
+
+	if err := c.checkFilters(o, state); err != nil {
+		return err
+	}
+
+	if o.IsTrailing() {
+		o.TrailingMark = state.Close
+		if !o.TrailingDelta.IsPositive() || !o.TrailingStop(o.TrailingMark).IsPositive() {
+			return ErrInvalidTrailing
+		}
+	}
+
+	o.Taker = !o.IsConditional() && crosses(o, c.crossPrice(o, state))
+	if o.Type == api.OrderType_LIMIT_MAKER && o.Taker {
+		return ErrWouldMatch
+	}
+
+	return nil
+}
+
+// crossPrice returns a price the order crosses to take liquidity: the best opposite level of the symbol book
+// or the state close price without book liquidity
+func (c *Client) crossPrice(o *order.Order, state parser.ExchangeState) decimal.Decimal {
+	if b, ok := c.books[o.Symbol]; ok {
+		if best, ok := b.Best(o.Side == api.OrderSide_SELL); ok {
+			return best.Price
+		}
+	}
+	return state.Close
+}
+
+// checkFilters checks the order against exchange info filters of its symbol.
+// Market orders are estimated at the state close price, stop market orders at the stop price.
+func (c *Client) checkFilters(o *order.Order, state parser.ExchangeState) error {
+	s, ok := c.info.Symbol(o.Symbol)
+	if !ok {
+		return nil
+	}
+
+	notional := o.Total
+	switch {
+	case o.Type == api.OrderType_MARKET, o.IsTrailing():
+		notional = o.Quantity.Mul(state.Close)
+	case o.IsMarket():
+		notional = o.Quantity.Mul(o.StopPrice)
+	default:
+		if err := s.PriceFilter.Check("price", o.Price); err != nil {
+			return err
+		}
+		if err := s.PercentPrice.Check(o.Price, state.Close); err != nil {
+			return err
+		}
+	}
+
+	if o.IsConditional() && !o.IsTrailing() {
+		if err := s.PriceFilter.Check("stop price", o.StopPrice); err != nil {
+			return err
+		}
+	}
+
+	if err := s.LotSize.Check(o.Quantity); err != nil {
+		return err
+	}
+
+	if err := s.Notional.Check(notional, o.IsMarket()); err != nil {
+		return err
+	}
+
+	if s.MaxNumOrders > 0 {
+		if open := c.openOrders(o.Symbol); open > s.MaxNumOrders {
+			return errors.Wrapf(info.ErrMaxNumOrders, "%d open orders exceed maximum %d", open, s.MaxNumOrders)
+		}
+	}
+
+	return nil
+}
+
+// openOrders returns a number of active orders of the symbol
+func (c *Client) openOrders(symbol string) int {
+	var open int
+	c.Order.Range(func(orders []*order.Order) {
+		for _, o := range orders {
+			if o.Symbol == symbol && !o.IsClosed() {
+				open++
+			}
+		}
+	})
+
+	return open
+}
+
+// reject removes the order without balance lock from active orders
+func (c *Client) reject(o *order.Order) {
+	c.Order.RemoveRange([]string{o.Id})
+	o.Status = api.OrderStatus_REJECTED
+}
+
+func (c *Client) lock(o *order.Order, state parser.ExchangeState) {
+	switch {
+	case o.Side == api.OrderSide_SELL:
+		o.Locked = o.Quantity
+	case o.Type == api.OrderType_MARKET, o.IsTrailing():
+		o.Locked = o.Quantity.Mul(state.Close).Mul(c.marketBuffer)
+	case o.IsMarket():
+		o.Locked = o.Quantity.Mul(o.StopPrice).Mul(c.marketBuffer)
+	default:
+		o.Locked = o.Total
+	}
+}
+
 // UpdateBalance updates user balance for order
 // pair USDT ETH
 // NEW order
-// 1. BUY:  USDT free-total
-// 2. BUY:  USDT locked+total
+// 1. BUY:  USDT free-locked
+// 2. BUY:  USDT locked+locked
 // 1. SELL: ETH  free-quantity
 // 2. SELL: ETH  locked+quantity
-// CANCEL order
-// 1. BUY:  USDT locked-total
-// 2. BUY:  USDT free+total
-// 1. SELL: ETH  locked-quantity
-// 2. SELL: ETH  free+quantity
+// CANCEL, EXPIRED or REJECTED order
+// 1. BUY:  USDT locked-locked
+// 2. BUY:  USDT free+locked
+// 1. SELL: ETH  locked-locked
+// 2. SELL: ETH  free+locked
+// PARTIALLY_FILLED order
+// 1. BUY:  USDT locked-lastTotal
+// 2. BUY:  ETH  free+lastQuantity
+// 1. SELL: ETH  locked-lastQuantity
+// 2. SELL: USDT free+lastTotal
 // FILL order
-// 1. BUY:  USDT locked-total
-// 2. BUY:  ETH  free+quantity
-// 1. SELL: ETH  locked-quantity
-// 2. SELL: USDT free+total
+// 1. BUY:  USDT locked-locked
+// 2. BUY:  USDT free+(locked-lastTotal)
+// 3. BUY:  ETH  free+lastQuantity
+// 1. SELL: ETH  locked-locked
+// 2. SELL: USDT free+lastTotal
+// Locked equals total for limit orders and an estimate for market orders, it's decreased by every fill.
+// Fill over the estimate spends free balance. Balance isn't changed if it would become negative.
 func (c *Client) UpdateBalance(o *order.Order) error {
-	var base, quote string
+	// spent asset is locked, received one is credited on fill
+	spent, received := o.BaseAsset, o.QuoteAsset
 	if o.GetSide() == api.OrderSide_BUY {
-		base, quote = o.Symbol[3:], o.Symbol[:3]
-	} else {
-		base, quote = o.Symbol[:3], o.Symbol[3:]
+		spent, received = o.QuoteAsset, o.BaseAsset
 	}
 
-	err := c.Balance.NewTransaction(base, func(asset *balance.Asset) (err error) {
+	locked := o.Locked
+	err := c.Balance.NewTransaction(spent, func(data *balance.Asset) (err error) {
+		// the asset is changed on a copy kept only if it stays positive
+		asset := *data
 		c.Log.Trace().Str("side", o.Side.String()).Str("asset", asset.Name).
 			Str("free", asset.Free.String()).Str("locked", asset.Locked.String()).
 			Str("order", o.Id).Str("status", o.Status.String()).Msg("balance update started 1")
 		switch o.GetStatus() {
 		case api.OrderStatus_NEW:
-			switch o.GetSide() {
-			case api.OrderSide_BUY:
-				asset.Free = asset.Free.Sub(o.Total)
-				asset.Locked = asset.Locked.Add(o.Total)
-			case api.OrderSide_SELL:
-				asset.Free = asset.Free.Sub(o.Quantity)
-				asset.Locked = asset.Locked.Add(o.Quantity)
+			asset.Free = asset.Free.Sub(o.Locked)
+			asset.Locked = asset.Locked.Add(o.Locked)
+		case api.OrderStatus_PARTIALLY_FILLED, api.OrderStatus_FILLED:
+			spend := o.LastQuantity
+			if o.GetSide() == api.OrderSide_BUY {
+				spend = o.LastTotal
 			}
-		case api.OrderStatus_FILLED:
-			switch o.GetSide() {
-			case api.OrderSide_BUY:
-				asset.Locked = asset.Locked.Sub(o.Total)
-			case api.OrderSide_SELL:
-				asset.Locked = asset.Locked.Sub(o.Quantity)
-			}
-		case api.OrderStatus_CANCELED:
-			switch o.GetSide() {
-			case api.OrderSide_BUY:
-				asset.Locked = asset.Locked.Sub(o.Total)
-				asset.Free = asset.Free.Add(o.Total)
-			case api.OrderSide_SELL:
-				asset.Locked = asset.Locked.Sub(o.Quantity)
-				asset.Free = asset.Free.Add(o.Quantity)
+			release := decimal.Min(spend, o.Locked)
+			asset.Locked = asset.Locked.Sub(release)
+			asset.Free = asset.Free.Sub(spend.Sub(release))
+			locked = o.Locked.Sub(release)
+			if o.Status == api.OrderStatus_FILLED {
+				// release the rest of market order estimate
+				asset.Locked = asset.Locked.Sub(locked)
+				asset.Free = asset.Free.Add(locked)
+				locked = decimal.Zero
 			}
+		case api.OrderStatus_CANCELED, api.OrderStatus_EXPIRED, api.OrderStatus_REJECTED:
+			asset.Locked = asset.Locked.Sub(o.Locked)
+			asset.Free = asset.Free.Add(o.Locked)
+			locked = decimal.Zero
 		}
 		c.Log.Trace().Str("side", o.Side.String()).Str("asset", asset.Name).
 			Str("free", asset.Free.String()).Str("locked", asset.Locked.String()).
@@ -318,29 +804,26 @@ func (c *Client) UpdateBalance(o *order.Order) error {
 
 			return balance.ErrNegative
 		}
+		*data = asset
 		return
 	})
 	if err != nil {
 		return err
 	}
-	if o.Status == api.OrderStatus_FILLED {
-		err = c.Balance.NewTransaction(quote, func(asset *balance.Asset) (err error) {
+	o.Locked = locked
+
+	if o.Status == api.OrderStatus_FILLED || o.Status == api.OrderStatus_PARTIALLY_FILLED {
+		err = c.Balance.NewTransaction(received, func(data *balance.Asset) (err error) {
+			asset := *data
 			c.Log.Trace().Str("side", o.Side.String()).Str("asset", asset.Name).
 				Str("free", asset.Free.String()).Str("locked", asset.Locked.String()).
 				Str("order", o.Id).Str("status", o.Status.String()).Msg("balance update started 2")
+			rate := c.commissionRate(o)
 			switch o.GetSide() {
 			case api.OrderSide_BUY:
-				if c.commission.IsZero() {
-					asset.Free = asset.Free.Add(o.Quantity)
-				} else {
-					asset.Free = asset.Free.Add(o.Quantity.Mul(c.commission))
-				}
+				asset.Free = asset.Free.Add(o.LastQuantity.Mul(rate))
 			case api.OrderSide_SELL:
-				if c.commission.IsZero() {
-					asset.Free = asset.Free.Add(o.Total)
-				} else {
-					asset.Free = asset.Free.Add(o.Total.Mul(c.commission))
-				}
+				asset.Free = asset.Free.Add(o.LastTotal.Mul(rate))
 			}
 
 			if asset.Free.IsNegative() || asset.Locked.IsNegative() {
@@ -355,6 +838,7 @@ func (c *Client) UpdateBalance(o *order.Order) error {
 			c.Log.Trace().Str("side", o.Side.String()).Str("asset", asset.Name).
 				Str("free", asset.Free.String()).Str("locked", asset.Locked.String()).
 				Str("order", o.Id).Str("status", o.Status.String()).Msg("balance update finished 2")
+			*data = asset
 			return
 		})
 		if err != nil {
@@ -365,6 +849,31 @@ func (c *Client) UpdateBalance(o *order.Order) error {
 	return nil
 }
 
+// commission holds multipliers of received asset amount: 1 - fee
+type commission struct {
+	maker decimal.Decimal
+	taker decimal.Decimal
+}
+
+func newCommission(maker, taker float64) commission {
+	return commission{
+		maker: one.Sub(decimal.NewFromFloat(maker).Shift(-2)),
+		taker: one.Sub(decimal.NewFromFloat(taker).Shift(-2)),
+	}
+}
+
+// commissionRate returns a multiplier of received asset for the order fill
+func (c *Client) commissionRate(o *order.Order) decimal.Decimal {
+	cm, ok := c.commissions[o.Symbol]
+	if !ok {
+		cm = c.commission
+	}
+	if o.Taker {
+		return cm.taker
+	}
+	return cm.maker
+}
+
 func (c *Client) IsClosed() bool {
 	return atomic.LoadInt32(&c.closed) == 1
 }
@@ -0,0 +1,183 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/phuslu/log"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/gen/proto/api"
+	"github.com/xenking/exchange-emulator/internal/balance"
+	"github.com/xenking/exchange-emulator/internal/parser"
+)
+
+// newReplayClient returns a client replaying minute klines of ETHUSDT by the clock policy
+func newReplayClient(t *testing.T, clock string, realtime, step bool) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	var sb strings.Builder
+	sb.WriteString("unix,date,symbol,open,high,low,close,Volume ETH,Volume USDT\n")
+	for i := int64(0); i < 10000; i++ {
+		fmt.Fprintf(&sb, "%d,,ETH/USDT,100,100,100,100,10,1000\n", 1640995200000+i*60000)
+	}
+	file := filepath.Join(dir, "klines.csv")
+	if err := os.WriteFile(file, []byte(sb.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		Parser: config.ParserConfig{
+			File:          file,
+			CacheDir:      dir,
+			ListenerDelay: time.Millisecond,
+			Validation:    config.ValidationConfig{Gaps: "ignore", Duplicates: "skip", OHLC: "ignore", ZeroVolume: "ignore"},
+			Realtime:      realtime,
+			Step:          step,
+		},
+		Exchange: testConfig,
+	}
+	cfg.Exchange.Clock = clock
+	p, err := parser.New(cfg.Parser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := p.NewListener(cfg.Parser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := New(ctx, cfg, newTestInfo(t), listener, &log.Logger{Level: log.PanicLevel})
+	c.Balance.Set([]balance.Asset{{Name: "USDT", Free: decimal.RequireFromString("1000")}})
+	return c
+}
+
+// checkClock waits until the clock runs as wanted and checks the replay time moves only while it runs
+func checkClock(t *testing.T, c *Client, running bool) {
+	t.Helper()
+	ctx := context.Background()
+	deadline := time.Now().Add(time.Second)
+	_, current, unix := c.Clock(ctx)
+	for current != running {
+		if time.Now().After(deadline) {
+			t.Fatalf("clock running = %t, want %t", current, running)
+		}
+		time.Sleep(time.Millisecond)
+		_, current, unix = c.Clock(ctx)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	// every action adds 1 ms to the time, klines add a minute
+	_, _, next := c.Clock(ctx)
+	if moved := next-unix >= 60000; moved != running {
+		t.Fatalf("replay time moved from %d to %d while clock running = %t", unix, next, running)
+	}
+}
+
+func TestClock(t *testing.T) {
+	limit := &api.Order{
+		Id: "1", Symbol: "ETHUSDT", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "50", Quantity: "1",
+	}
+
+	tests := []struct {
+		name   string
+		clock  string
+		idle   bool
+		active bool
+	}{
+		{name: "always", clock: ClockAlways, idle: true, active: true},
+		{name: "orders", clock: ClockOrders, idle: false, active: true},
+		{name: "manual", clock: ClockManual, idle: false, active: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newReplayClient(t, tt.clock, false, false)
+			ctx := context.Background()
+
+			if policy, _, _ := c.Clock(ctx); policy != tt.clock {
+				t.Fatalf("clock policy = %s, want %s", policy, tt.clock)
+			}
+			checkClock(t, c, tt.idle)
+
+			var err error
+			c.NewAction(ctx, func(state parser.ExchangeState) {
+				_, err = c.AddOrder(limit, state)
+			})
+			if err != nil {
+				t.Fatalf("AddOrder() error = %v", err)
+			}
+			checkClock(t, c, tt.active)
+
+			c.NewAction(ctx, func(_ parser.ExchangeState) {
+				err = c.CancelOrder(limit.Id)
+			})
+			if err != nil {
+				t.Fatalf("CancelOrder() error = %v", err)
+			}
+			checkClock(t, c, tt.idle)
+		})
+	}
+}
+
+func TestRealtimeClock(t *testing.T) {
+	c := newReplayClient(t, ClockOrders, true, false)
+	ctx := context.Background()
+	before := time.Now().UnixMilli()
+	policy, running, unix := c.Clock(ctx)
+	after := time.Now().UnixMilli()
+	if policy != ClockAlways || !running {
+		t.Fatalf("clock = %s running %t, want %s running", policy, running, ClockAlways)
+	}
+	// actions happen at the wall time
+	if unix < before || unix > after {
+		t.Fatalf("replay time = %d, want the wall time %d-%d", unix, before, after)
+	}
+
+	// the first kline is the last closed one, it's stamped with its close time
+	var closed int64
+	c.NewAction(ctx, func(parser.ExchangeState) {
+		closed = c.states["ETHUSDT"].Unix
+	})
+	if closed%60000 != 59999 || closed < before-60000 || closed > after {
+		t.Fatalf("kline time = %d, want the close of the last closed minute of %d", closed, before)
+	}
+}
+
+func TestAdvancePaused(t *testing.T) {
+	c := newReplayClient(t, ClockManual, false, true)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := c.Advance(ctx, 1); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	_, _, unix := c.Clock(ctx)
+
+	c.Parser.Pause()
+	if err := c.Advance(ctx, 1); !errors.Is(err, parser.ErrPausedStep) {
+		t.Fatalf("Advance() of paused replay error = %v, want %v", err, parser.ErrPausedStep)
+	}
+	// the exchange keeps serving actions
+	if _, _, next := c.Clock(ctx); ctx.Err() != nil || next != unix+1 {
+		t.Fatalf("clock = %d after the paused step, want %d: %v", next, unix+1, ctx.Err())
+	}
+
+	c.Parser.Resume()
+	if err := c.Advance(ctx, 1); err != nil {
+		t.Fatalf("Advance() after resume error = %v", err)
+	}
+	if _, _, next := c.Clock(ctx); next < unix+60000 {
+		t.Errorf("clock = %d after the step, want a minute after %d", next, unix)
+	}
+}
@@ -0,0 +1,460 @@
+package exchange
+
+import (
+	"sort"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/bytebufferpool"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/gen/proto/api"
+	"github.com/xenking/exchange-emulator/internal/balance"
+	"github.com/xenking/exchange-emulator/internal/book"
+	"github.com/xenking/exchange-emulator/internal/order"
+	"github.com/xenking/exchange-emulator/internal/parser"
+)
+
+// match is an order fill planned at the position of the kline price path
+type match struct {
+	order    *order.Order
+	position decimal.Decimal
+	price    decimal.Decimal
+}
+
+// matchOrders fills active orders on the state and returns ids of orders to remove from active ones:
+// completely filled orders and canceled orders of the same lists.
+// Orders are filled in the order the kline price path reaches their prices.
+// Only orders of the state symbol are matched, states without symbol match all orders.
+func (c *Client) matchOrders(state parser.ExchangeState, removed []string) []string {
+	path := newPricePath(c.pathModel, c.rnd, state)
+	// trade quantity is shared by orders filled on the tick
+	volume := state.Volume
+
+	c.Order.Range(func(orders []*order.Order) {
+		matches := c.matches[:0]
+		for _, o := range orders {
+			if o.IsClosed() || (state.Symbol != "" && o.Symbol != state.Symbol) {
+				continue
+			}
+
+			if o.TimeInForce == api.TimeInForce_GTD && state.Unix >= o.GoodTillTime {
+				c.expire(o, api.OrderStatus_EXPIRED)
+				removed = append(removed, o.Id)
+				if o.OrderListId != "" {
+					removed = c.cancelList(orders, o, removed)
+				}
+				continue
+			}
+
+			if m, ok := c.matchPath(o, state, path); ok {
+				matches = append(matches, m)
+			}
+		}
+
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].position.LessThan(matches[j].position)
+		})
+
+		for _, m := range matches {
+			o := m.order
+			// order could be canceled by its list filled earlier on the path
+			if o.IsClosed() {
+				continue
+			}
+
+			if o.IsConditional() && !o.Triggered {
+				c.trigger(o, state)
+			}
+
+			var qty, total decimal.Decimal
+			b, taking := c.books[o.Symbol]
+			taking = taking && o.Taker
+			if taking {
+				qty, total = c.takeBook(o, b)
+			} else {
+				qty = c.fillQuantity(o, state, volume)
+				total = qty.Mul(m.price)
+			}
+			if qty.IsZero() {
+				// limit order the book doesn't cross rests as a maker one filled on the price path
+				if taking && !o.IsMarket() {
+					o.Taker = false
+				}
+				continue
+			}
+
+			if o.OrderListId != "" {
+				holdListLock(orders, o)
+			}
+
+			unpaid, err := c.fill(o, qty, total)
+			if err != nil {
+				c.Log.Error().Err(err).Str("user", o.UserId).Str("order", o.Id).Msg("can't update balance")
+				continue
+			}
+			// the rest of limit order stays on the book as a maker one after the taker fill is settled
+			if taking && !o.IsMarket() {
+				o.Taker = false
+			}
+			if state.Tick && !taking {
+				volume = volume.Sub(o.LastQuantity)
+			}
+
+			if !o.LastQuantity.IsZero() {
+				c.Log.Debug().Str("order", o.Id).Uint64("internal", o.OrderId).Str("user", o.UserId).Str("symbol", o.Symbol).
+					Str("side", o.Side.String()).Str("price", o.LastTotal.Div(o.LastQuantity).String()).
+					Str("qty", o.LastQuantity.String()).Str("status", o.Status.String()).Int64("ts", state.Unix).
+					Msg("order filled")
+
+				c.sendOrder(o)
+			}
+
+			if unpaid {
+				c.expire(o, api.OrderStatus_EXPIRED)
+			}
+			if o.IsClosed() {
+				removed = append(removed, o.Id)
+			}
+
+			if o.OrderListId != "" {
+				removed = c.cancelList(orders, o, removed)
+			}
+		}
+
+		// triggered stop limit orders that are not filled on the path rest as limit orders
+		for _, o := range orders {
+			if state.Symbol != "" && o.Symbol != state.Symbol {
+				continue
+			}
+			if o.IsConditional() && !o.IsMarket() && !o.Triggered && !o.IsClosed() {
+				if _, ok := path.reach(o.StopPrice, o.TriggersBelow(), decimal.Zero); ok {
+					c.trigger(o, state)
+				}
+			}
+		}
+
+		c.matches = matches
+	})
+
+	return removed
+}
+
+// holdListLock moves the balance lock of the order list to its order being filled
+func holdListLock(orders []*order.Order, o *order.Order) {
+	for _, lo := range orders {
+		if lo != o && lo.OrderListId == o.OrderListId && !lo.IsClosed() {
+			o.Locked = o.Locked.Add(lo.Locked)
+			lo.Locked = decimal.Zero
+		}
+	}
+}
+
+// cancelList cancels other orders of the filled or expired order list
+func (c *Client) cancelList(orders []*order.Order, filled *order.Order, removed []string) []string {
+	for _, o := range orders {
+		if o == filled || o.OrderListId != filled.OrderListId || o.IsClosed() {
+			continue
+		}
+
+		o.Status = api.OrderStatus_CANCELED
+		if err := c.UpdateBalance(o); err != nil {
+			c.Log.Error().Err(err).Str("user", o.UserId).Str("order", o.Id).Msg("can't update balance")
+		}
+
+		c.Log.Debug().Str("order", o.Id).Str("list", o.OrderListId).Str("filled", filled.Id).
+			Msg("order list canceled")
+
+		removed = append(removed, o.Id)
+		c.sendOrder(o)
+	}
+
+	return removed
+}
+
+// matchPath returns a position on the price path and a price the order is filled at.
+// Orders placed before the kline and reached at its open are filled at open price when the kline gaps over them.
+// Orders taking liquidity of the symbol order book are filled on the book at the path start.
+func (c *Client) matchPath(o *order.Order, state parser.ExchangeState, path pricePath) (match, bool) {
+	m := match{order: o}
+	resting := o.TransactTime < state.Unix
+
+	if _, ok := c.books[o.Symbol]; ok && o.Taker && (!o.IsConditional() || o.Triggered) {
+		return m, true
+	}
+
+	if o.IsTrailing() && !o.Triggered {
+		pos, level, mark, ok := path.trail(o.TrailingMark, o.TriggersBelow(), o.TrailingStop)
+		o.TrailingMark = mark
+		if !ok {
+			return m, false
+		}
+
+		// trailing stop is filled at market on trigger, its stop is reported to the client
+		o.StopPrice = level
+		o.Order.StopPrice = level.String()
+		m.position, m.price = pos, level
+		if resting && pos.IsZero() {
+			m.price = state.Open
+		}
+		return m, true
+	}
+
+	if o.IsConditional() && !o.Triggered {
+		pos, ok := path.reach(o.StopPrice, o.TriggersBelow(), decimal.Zero)
+		if !ok {
+			return m, false
+		}
+
+		// stop market order is filled at stop price on trigger
+		if o.IsMarket() {
+			m.position, m.price = pos, o.StopPrice
+			if resting && pos.IsZero() {
+				m.price = state.Open
+			}
+			return m, true
+		}
+		m.position = pos
+	}
+
+	if o.IsMarket() {
+		m.price = c.marketFillPrice(o.Side, state)
+		return m, true
+	}
+
+	// buy
+	// price=10, high=12, low=9 -> bought
+	// price=10, high=15, low=11 -> continue
+	// price=10, high=9, low=8 -> bought
+	// price >= low
+	// sell
+	// price=10, high=12, low=9 -> sold
+	// price=10, high=15, low=11 -> sold
+	// price=10, high=9, low=8 -> continue
+	// price <= high
+	pos, ok := path.reach(o.Price, o.Side == api.OrderSide_BUY, m.position)
+	if !ok {
+		return m, false
+	}
+	m.position, m.price = pos, o.Price
+	// a trade through the price of resting order fills it at its price, only a kline can gap over it
+	if resting && pos.IsZero() && !state.Tick {
+		m.price = state.Open
+	}
+
+	return m, true
+}
+
+// trigger converts conditional order to market or limit one
+func (c *Client) trigger(o *order.Order, state parser.ExchangeState) {
+	o.Triggered = true
+	// triggered order takes liquidity if it's executed at market or its limit crosses stop price
+	o.Taker = o.IsMarket() || crosses(o, o.StopPrice)
+
+	c.Log.Debug().Str("order", o.Id).Str("type", o.Type.String()).Str("stop", o.StopPrice.String()).
+		Int64("ts", state.Unix).Msg("order triggered")
+}
+
+// fillImmediate fills IOC and FOK orders on the current state close price and expires the rest.
+// FOK order is rejected if it can't be filled completely.
+func (c *Client) fillImmediate(o *order.Order, state parser.ExchangeState) {
+	defer c.Order.RemoveRange([]string{o.Id})
+
+	b, hasBook := c.books[o.Symbol]
+
+	qty := decimal.Zero
+	switch {
+	case o.Taker && hasBook:
+		qty = decimal.Min(o.Quantity, b.Available(o.Side == api.OrderSide_BUY, bookLimit(o)))
+	case o.Taker:
+		qty = c.fillQuantity(o, state, state.Volume)
+	}
+
+	if o.TimeInForce == api.TimeInForce_FOK && !qty.Equal(o.Quantity) {
+		c.expire(o, api.OrderStatus_REJECTED)
+		return
+	}
+
+	if !qty.IsZero() {
+		total := qty.Mul(state.Close)
+		if hasBook {
+			qty, total = c.takeBook(o, b)
+		}
+
+		if _, err := c.fill(o, qty, total); err != nil {
+			c.Log.Error().Err(err).Str("user", o.UserId).Str("order", o.Id).Msg("can't update balance")
+		} else if !o.LastQuantity.IsZero() {
+			c.sendOrder(o)
+		}
+	}
+
+	// the rest of order not paid by the balance is expired too
+	if o.Status != api.OrderStatus_FILLED {
+		c.expire(o, api.OrderStatus_EXPIRED)
+	}
+}
+
+// expire releases locked balance of the order and notifies about the new status
+func (c *Client) expire(o *order.Order, status api.OrderStatus) {
+	o.Status = status
+	if err := c.UpdateBalance(o); err != nil {
+		c.Log.Error().Err(err).Str("user", o.UserId).Str("order", o.Id).Msg("can't update balance")
+	}
+
+	c.Log.Debug().Str("order", o.Id).Str("status", status.String()).Msg("order expired")
+
+	c.sendOrder(o)
+}
+
+// crosses reports whether the order is executed immediately at the price
+func crosses(o *order.Order, price decimal.Decimal) bool {
+	if o.IsMarket() {
+		return true
+	}
+	if o.Side == api.OrderSide_BUY {
+		return o.Price.GreaterThanOrEqual(price)
+	}
+	return o.Price.LessThanOrEqual(price)
+}
+
+// fillQuantity returns a quantity of the order that can be filled on the state volume.
+// Trade fills are limited by the trade quantity left after other orders filled on the trade.
+func (c *Client) fillQuantity(o *order.Order, state parser.ExchangeState, volume decimal.Decimal) decimal.Decimal {
+	remaining := o.Quantity.Sub(o.Executed)
+	if state.Tick && volume.LessThan(remaining) {
+		remaining = decimal.Max(volume, decimal.Zero)
+	}
+	if c.participation.IsZero() {
+		return remaining
+	}
+
+	available := state.Volume.Mul(c.participation)
+	if available.LessThan(remaining) {
+		return available
+	}
+
+	return remaining
+}
+
+// fill executes qty of the order for the quote total and settles the balance.
+// Buy fill over the locked estimate and free balance is reduced to the paid quantity,
+// it reports the order is unpaid and the rest of it should be expired.
+func (c *Client) fill(o *order.Order, qty, total decimal.Decimal) (unpaid bool, err error) {
+	err = c.settle(o, qty, total)
+	if !errors.Is(err, balance.ErrNegative) || o.Side != api.OrderSide_BUY {
+		return false, err
+	}
+
+	qty, total = c.paid(o, qty, total)
+	if !qty.IsPositive() {
+		o.LastQuantity, o.LastTotal = decimal.Zero, decimal.Zero
+		return true, nil
+	}
+
+	return true, c.settle(o, qty, total)
+}
+
+// paid returns the part of buy fill paid by the order lock and free balance
+func (c *Client) paid(o *order.Order, qty, total decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	var free decimal.Decimal
+	_ = c.Balance.NewTransaction(o.QuoteAsset, func(asset *balance.Asset) error {
+		free = asset.Free
+		return nil
+	})
+	funds := o.Locked.Add(decimal.Max(free, decimal.Zero))
+
+	price := total.Div(qty)
+	paid := funds.Div(price)
+	if s, ok := c.info.Symbol(o.Symbol); ok && s.LotSize != nil && s.LotSize.StepSize.IsPositive() {
+		paid = paid.Sub(paid.Mod(s.LotSize.StepSize))
+	} else {
+		paid = paid.Truncate(quantityPrecision)
+	}
+
+	return paid, decimal.Min(paid.Mul(price), funds)
+}
+
+// settle executes qty of the order for the quote total and updates the balance
+func (c *Client) settle(o *order.Order, qty, total decimal.Decimal) error {
+	executed, status := o.Executed, o.Status
+
+	o.LastQuantity = qty
+	o.LastTotal = total
+	o.Executed = o.Executed.Add(qty)
+	if o.Executed.Equal(o.Quantity) {
+		o.Status = api.OrderStatus_FILLED
+	} else {
+		o.Status = api.OrderStatus_PARTIALLY_FILLED
+	}
+
+	if err := c.UpdateBalance(o); err != nil {
+		o.Executed, o.Status = executed, status
+		o.LastQuantity, o.LastTotal = decimal.Zero, decimal.Zero
+		return err
+	}
+
+	o.ExecutedTotal = o.ExecutedTotal.Add(o.LastTotal)
+	o.Order.ExecutedQuantity = o.Executed.String()
+	o.Order.FillPrice = o.ExecutedTotal.Div(o.Executed).String()
+	if o.IsMarket() {
+		o.Total = o.ExecutedTotal
+		o.Order.Total = o.Total.String()
+	}
+
+	return nil
+}
+
+// quantityPrecision is a precision of quantities reduced to the paid part without lot size filter
+const quantityPrecision = 8
+
+// takeBook fills the order on book levels up to its limit price and returns the quantity with volume weighted total
+func (c *Client) takeBook(o *order.Order, b *book.Book) (qty, total decimal.Decimal) {
+	return b.Take(o.Side == api.OrderSide_BUY, o.Quantity.Sub(o.Executed), bookLimit(o))
+}
+
+// bookLimit returns a price limit of walking the book, market orders take any price
+func bookLimit(o *order.Order) decimal.Decimal {
+	if o.IsMarket() {
+		return decimal.Zero
+	}
+	return o.Price
+}
+
+func (c *Client) sendOrder(o *order.Order) {
+	buf := bytebufferpool.GetLen(29)
+	buf.B = buf.B[:0]
+	buf.B = o.AppendEncoded(buf.B)
+	err := c.orderConn.Send(buf.B)
+	bytebufferpool.Put(buf)
+	if err != nil {
+		c.Log.Error().Err(err).Str("user", c.orderConn.ID).Str("order", o.Id).Msg("can't send order update")
+	}
+}
+
+type marketPrice int8
+
+const (
+	marketPriceClose marketPrice = iota
+	marketPriceOpen
+	marketPriceWorst
+)
+
+var marketPrices = map[string]marketPrice{
+	"close": marketPriceClose,
+	"open":  marketPriceOpen,
+	"worst": marketPriceWorst,
+}
+
+// marketFillPrice returns a state price market orders are filled on
+func (c *Client) marketFillPrice(side api.OrderSide, state parser.ExchangeState) decimal.Decimal {
+	switch c.marketPrice {
+	case marketPriceOpen:
+		return state.Open
+	case marketPriceWorst:
+		if side == api.OrderSide_BUY {
+			return state.High
+		}
+		return state.Low
+	default:
+		return state.Close
+	}
+}
@@ -0,0 +1,733 @@
+package exchange
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/phuslu/log"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/gen/proto/api"
+	"github.com/xenking/exchange-emulator/internal/balance"
+	"github.com/xenking/exchange-emulator/internal/book"
+	"github.com/xenking/exchange-emulator/internal/info"
+	"github.com/xenking/exchange-emulator/internal/order"
+	"github.com/xenking/exchange-emulator/internal/parser"
+)
+
+var testConfig = config.ExchangeConfig{
+	MarketPrice:  "close",
+	MarketBuffer: 5,
+	PricePath:    "ohlc",
+}
+
+// newTestInfo returns exchange info listing ETHUSDT without filters
+func newTestInfo(t *testing.T) *info.Info {
+	t.Helper()
+	i, err := info.Parse([]byte(`{"symbols":[{"symbol":"ETHUSDT","baseAsset":"ETH","quoteAsset":"USDT"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i
+}
+
+// newTestClient returns a client matching orders without a listener, balances are free amounts by asset
+func newTestClient(t *testing.T, cfg config.ExchangeConfig, balances map[string]string) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	logger := &log.Logger{Level: log.PanicLevel}
+	b := balance.New()
+	b.SetLogger(logger)
+	go b.Start(ctx)
+	assets := make([]balance.Asset, 0, len(balances))
+	for asset, free := range balances {
+		assets = append(assets, balance.Asset{Name: asset, Free: decimal.RequireFromString(free)})
+	}
+	b.Set(assets)
+
+	o := order.New()
+	o.SetLogger(logger)
+	go o.Start(ctx)
+
+	commissions := make(map[string]commission, len(cfg.Commissions))
+	for _, cc := range cfg.Commissions {
+		commissions[cc.Symbol] = newCommission(cc.Maker, cc.Taker)
+	}
+
+	return &Client{
+		Parser:        &parser.Listener{},
+		Balance:       b,
+		Order:         o,
+		Log:           logger,
+		info:          newTestInfo(t),
+		commission:    newCommission(cfg.MakerCommission, cfg.TakerCommission),
+		commissions:   commissions,
+		marketBuffer:  one.Add(decimal.NewFromFloat(cfg.MarketBuffer).Shift(-2)),
+		participation: decimal.NewFromFloat(cfg.ParticipationRate).Shift(-2),
+		marketPrice:   marketPrices[cfg.MarketPrice],
+		pathModel:     pathModels[cfg.PricePath],
+		rnd:           rand.New(rand.NewSource(1)), //nolint:gosec
+		states:        make(map[string]parser.ExchangeState),
+		books:         make(map[string]*book.Book),
+	}
+}
+
+// kline returns ETHUSDT kline of the minute
+func kline(minute int64, open, high, low, close, volume string) parser.ExchangeState {
+	return parser.ExchangeState{
+		Symbol: "ETHUSDT",
+		Open:   decimal.RequireFromString(open),
+		High:   decimal.RequireFromString(high),
+		Low:    decimal.RequireFromString(low),
+		Close:  decimal.RequireFromString(close),
+		Volume: decimal.RequireFromString(volume),
+		Unix:   minute * 60000,
+	}
+}
+
+// place adds the order on the state
+func place(t *testing.T, c *Client, state parser.ExchangeState, o *api.Order) *order.Order {
+	t.Helper()
+	c.setState(state)
+	if o.Symbol == "" {
+		o.Symbol = "ETHUSDT"
+	}
+	placed, err := c.AddOrder(o, state)
+	if err != nil {
+		t.Fatalf("AddOrder() error = %v", err)
+	}
+	return placed
+}
+
+// replay matches active orders on the state like the exchange loop
+func replay(c *Client, state parser.ExchangeState) {
+	c.setState(state)
+	if removed := c.matchOrders(state, nil); len(removed) > 0 {
+		c.Order.RemoveRange(removed)
+	}
+}
+
+func checkBalance(t *testing.T, c *Client, asset, free, locked string) {
+	t.Helper()
+	for _, a := range c.Balance.List() {
+		if a.Name != asset {
+			continue
+		}
+		if !a.Free.Equal(decimal.RequireFromString(free)) || !a.Locked.Equal(decimal.RequireFromString(locked)) {
+			t.Fatalf("%s balance = %s free, %s locked, want %s free, %s locked", asset, a.Free, a.Locked, free, locked)
+		}
+		return
+	}
+	t.Fatalf("%s balance not found", asset)
+}
+
+func checkOrder(t *testing.T, o *order.Order, status api.OrderStatus, executed, fillPrice string) {
+	t.Helper()
+	if o.Status != status || !o.Executed.Equal(decimal.RequireFromString(executed)) ||
+		(fillPrice != "" && o.Order.FillPrice != fillPrice) {
+		t.Fatalf("order = %s executed %s at %s, want %s executed %s at %s",
+			o.Status, o.Executed, o.Order.FillPrice, status, executed, fillPrice)
+	}
+}
+
+func TestMarketOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		marketPrice string
+		side        api.OrderSide
+		want        string
+		usdt        string
+		eth         string
+	}{
+		{name: "buy at close", marketPrice: "close", side: api.OrderSide_BUY, want: "102", usdt: "898", eth: "11"},
+		{name: "buy at open", marketPrice: "open", side: api.OrderSide_BUY, want: "101", usdt: "899", eth: "11"},
+		{name: "buy at worst", marketPrice: "worst", side: api.OrderSide_BUY, want: "104", usdt: "896", eth: "11"},
+		{name: "sell at worst", marketPrice: "worst", side: api.OrderSide_SELL, want: "99", usdt: "1099", eth: "9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig
+			cfg.MarketPrice = tt.marketPrice
+			c := newTestClient(t, cfg, map[string]string{"USDT": "1000", "ETH": "10"})
+
+			o := place(t, c, kline(0, "100", "100", "100", "100", "10"),
+				&api.Order{Id: "1", Side: tt.side, Type: api.OrderType_MARKET, Quantity: "1"})
+			// market buy locks the estimate with the buffer until the next kline
+			if tt.side == api.OrderSide_BUY {
+				checkBalance(t, c, "USDT", "895", "105")
+			} else {
+				checkBalance(t, c, "ETH", "9", "1")
+			}
+			checkOrder(t, o, api.OrderStatus_NEW, "0", "")
+
+			replay(c, kline(1, "101", "104", "99", "102", "10"))
+			checkOrder(t, o, api.OrderStatus_FILLED, "1", tt.want)
+			checkBalance(t, c, "USDT", tt.usdt, "0")
+			checkBalance(t, c, "ETH", tt.eth, "0")
+			if c.Order.Active() != 0 {
+				t.Fatalf("filled order is active")
+			}
+		})
+	}
+}
+
+func TestMarketBuyOverrun(t *testing.T) {
+	tests := []struct {
+		name     string
+		usdt     string
+		status   api.OrderStatus
+		executed string
+		wantUSDT string
+	}{
+		{name: "covered by free balance", usdt: "200", status: api.OrderStatus_FILLED, executed: "1", wantUSDT: "90"},
+		{name: "paid part is filled", usdt: "105", status: api.OrderStatus_EXPIRED, executed: "0.95454545", wantUSDT: "0.0000005"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, testConfig, map[string]string{"USDT": tt.usdt})
+
+			o := place(t, c, kline(0, "100", "100", "100", "100", "10"),
+				&api.Order{Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_MARKET, Quantity: "1"})
+
+			// the kline closes over the locked estimate of 105
+			replay(c, kline(1, "100", "110", "100", "110", "10"))
+			checkOrder(t, o, tt.status, tt.executed, "")
+			checkBalance(t, c, "USDT", tt.wantUSDT, "0")
+			checkBalance(t, c, "ETH", tt.executed, "0")
+
+			// closed order isn't filled again
+			replay(c, kline(2, "110", "120", "110", "120", "10"))
+			checkBalance(t, c, "USDT", tt.wantUSDT, "0")
+			if c.Order.Active() != 0 {
+				t.Fatalf("closed order is active")
+			}
+		})
+	}
+}
+
+func TestInsufficientBalance(t *testing.T) {
+	c := newTestClient(t, testConfig, map[string]string{"USDT": "50"})
+	state := kline(0, "101", "101", "101", "101", "10")
+	c.setState(state)
+
+	o, err := c.AddOrder(&api.Order{
+		Id: "1", Symbol: "ETHUSDT", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "100", Quantity: "1",
+	}, state)
+	if !errors.Is(err, balance.ErrNegative) {
+		t.Fatalf("AddOrder() error = %v, want %v", err, balance.ErrNegative)
+	}
+	checkOrder(t, o, api.OrderStatus_REJECTED, "0", "")
+	if active := c.Order.Active(); active != 0 {
+		t.Fatalf("active orders = %d, want 0", active)
+	}
+
+	replay(c, kline(1, "101", "101", "99", "100", "10"))
+	checkOrder(t, o, api.OrderStatus_REJECTED, "0", "")
+	checkBalance(t, c, "USDT", "50", "0")
+}
+
+func TestPartialFill(t *testing.T) {
+	cfg := testConfig
+	cfg.ParticipationRate = 50
+	c := newTestClient(t, cfg, map[string]string{"USDT": "1000"})
+
+	o := place(t, c, kline(0, "101", "101", "101", "101", "2"),
+		&api.Order{Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "100", Quantity: "2"})
+	checkBalance(t, c, "USDT", "800", "200")
+
+	// half of the kline volume is filled on every kline
+	replay(c, kline(1, "101", "101", "99", "100", "2"))
+	checkOrder(t, o, api.OrderStatus_PARTIALLY_FILLED, "1", "100")
+	checkBalance(t, c, "USDT", "800", "100")
+	checkBalance(t, c, "ETH", "1", "0")
+	if frame := string(o.AppendEncoded(nil)[9:]); frame != "1|100|1|1|100" {
+		t.Fatalf("partial fill frame = %s", frame)
+	}
+
+	replay(c, kline(2, "100", "101", "99", "100", "3"))
+	checkOrder(t, o, api.OrderStatus_FILLED, "2", "100")
+	checkBalance(t, c, "USDT", "800", "0")
+	checkBalance(t, c, "ETH", "2", "0")
+	if frame := string(o.AppendEncoded(nil)[9:]); frame != "1|100|2|1|100" {
+		t.Fatalf("fill frame = %s", frame)
+	}
+}
+
+func TestConditionalOrder(t *testing.T) {
+	t.Run("stop loss is filled at stop price", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1"})
+		o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_SELL, Type: api.OrderType_STOP_LOSS, StopPrice: "95", Quantity: "1",
+		})
+		checkBalance(t, c, "ETH", "0", "1")
+
+		replay(c, kline(1, "100", "101", "99", "100", "10"))
+		checkOrder(t, o, api.OrderStatus_NEW, "0", "")
+
+		replay(c, kline(2, "100", "101", "94", "96", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "95")
+		checkBalance(t, c, "ETH", "0", "0")
+		checkBalance(t, c, "USDT", "95", "0")
+	})
+
+	t.Run("take profit is filled at market", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"USDT": "1000"})
+		o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_TAKE_PROFIT, StopPrice: "90", Quantity: "1",
+		})
+		// stop market buy locks the stop price estimate
+		checkBalance(t, c, "USDT", "905.5", "94.5")
+
+		replay(c, kline(1, "95", "96", "89", "92", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "90")
+		checkBalance(t, c, "USDT", "910", "0")
+		checkBalance(t, c, "ETH", "1", "0")
+	})
+
+	t.Run("triggered stop limit rests as limit", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1"})
+		o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_SELL, Type: api.OrderType_STOP_LOSS_LIMIT, StopPrice: "95", Price: "97", Quantity: "1",
+		})
+
+		// the price doesn't come back to the limit after the trigger
+		replay(c, kline(1, "100", "101", "94", "94.5", "10"))
+		checkOrder(t, o, api.OrderStatus_NEW, "0", "")
+		if !o.Triggered {
+			t.Fatal("stop limit order isn't triggered")
+		}
+
+		replay(c, kline(2, "94.5", "98", "94", "96", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "97")
+		checkBalance(t, c, "USDT", "97", "0")
+	})
+}
+
+func TestTrailingStop(t *testing.T) {
+	t.Run("sell trails the high-water mark", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1"})
+		o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_SELL, Type: api.OrderType_TRAILING_STOP, Quantity: "1",
+			TrailingDelta: "5", TrailingDeltaType: api.TrailingDeltaType_ABSOLUTE,
+		})
+
+		// the mark rises to the high, the low stays over its stop
+		replay(c, kline(1, "100", "110", "106", "108", "10"))
+		checkOrder(t, o, api.OrderStatus_NEW, "0", "")
+		if !o.TrailingMark.Equal(decimal.NewFromInt(110)) {
+			t.Fatalf("trailing mark = %s, want 110", o.TrailingMark)
+		}
+
+		// the mark rises to 112 and price retraces to its stop
+		replay(c, kline(2, "108", "112", "100", "101", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "107")
+		if o.Order.StopPrice != "107" {
+			t.Fatalf("stop price = %s, want 107", o.Order.StopPrice)
+		}
+		checkBalance(t, c, "ETH", "0", "0")
+		checkBalance(t, c, "USDT", "107", "0")
+	})
+
+	t.Run("buy trails the low-water mark in bips", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"USDT": "200"})
+		o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_TRAILING_STOP, Quantity: "1",
+			TrailingDelta: "500", TrailingDeltaType: api.TrailingDeltaType_BIPS,
+		})
+		checkBalance(t, c, "USDT", "95", "105")
+
+		replay(c, kline(1, "100", "101", "90", "92", "10"))
+		checkOrder(t, o, api.OrderStatus_NEW, "0", "")
+
+		// 5% over the low-water mark of 90
+		replay(c, kline(2, "92", "96", "91", "95", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "94.5")
+		if o.Order.StopPrice != "94.5" {
+			t.Fatalf("stop price = %s, want 94.5", o.Order.StopPrice)
+		}
+		checkBalance(t, c, "USDT", "105.5", "0")
+		checkBalance(t, c, "ETH", "1", "0")
+	})
+
+	t.Run("gap over the stop is filled at open", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1"})
+		o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_SELL, Type: api.OrderType_TRAILING_STOP, Quantity: "1",
+			TrailingDelta: "5", TrailingDeltaType: api.TrailingDeltaType_ABSOLUTE,
+		})
+
+		replay(c, kline(1, "100", "110", "106", "108", "10"))
+		replay(c, kline(2, "100", "102", "98", "99", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "100")
+		if o.Order.StopPrice != "105" {
+			t.Fatalf("stop price = %s, want 105", o.Order.StopPrice)
+		}
+		checkBalance(t, c, "USDT", "100", "0")
+	})
+}
+
+func TestOrderList(t *testing.T) {
+	newList := func() *api.OrderList {
+		return &api.OrderList{Id: "oco", Orders: []*api.Order{
+			{Id: "1", Symbol: "ETHUSDT", Side: api.OrderSide_SELL, Type: api.OrderType_LIMIT, Price: "110", Quantity: "1"},
+			{Id: "2", Symbol: "ETHUSDT", Side: api.OrderSide_SELL, Type: api.OrderType_STOP_LOSS, StopPrice: "95", Quantity: "1"},
+		}}
+	}
+
+	t.Run("fill cancels the other order", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1"})
+		state := kline(0, "100", "100", "100", "100", "10")
+		c.setState(state)
+		orders, err := c.AddOrderList(newList(), state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the list locks one quantity
+		checkBalance(t, c, "ETH", "0", "1")
+
+		replay(c, kline(1, "100", "111", "99", "105", "10"))
+		checkOrder(t, orders[0], api.OrderStatus_FILLED, "1", "110")
+		checkOrder(t, orders[1], api.OrderStatus_CANCELED, "0", "")
+		checkBalance(t, c, "ETH", "0", "0")
+		checkBalance(t, c, "USDT", "110", "0")
+		if c.Order.Active() != 0 {
+			t.Fatal("order list is active")
+		}
+	})
+
+	t.Run("fill of the other order takes the lock", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1"})
+		state := kline(0, "100", "100", "100", "100", "10")
+		c.setState(state)
+		orders, err := c.AddOrderList(newList(), state)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		replay(c, kline(1, "100", "101", "94", "96", "10"))
+		checkOrder(t, orders[1], api.OrderStatus_FILLED, "1", "95")
+		checkOrder(t, orders[0], api.OrderStatus_CANCELED, "0", "")
+		checkBalance(t, c, "ETH", "0", "0")
+		checkBalance(t, c, "USDT", "95", "0")
+	})
+
+	t.Run("cancel of one order cancels the list", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1"})
+		state := kline(0, "100", "100", "100", "100", "10")
+		c.setState(state)
+		orders, err := c.AddOrderList(newList(), state)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = c.CancelOrder("2"); err != nil {
+			t.Fatal(err)
+		}
+		checkOrder(t, orders[0], api.OrderStatus_CANCELED, "0", "")
+		checkOrder(t, orders[1], api.OrderStatus_CANCELED, "0", "")
+		checkBalance(t, c, "ETH", "1", "0")
+		if c.Order.Active() != 0 {
+			t.Fatal("order list is active")
+		}
+	})
+
+	t.Run("list isn't placed without balance", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "0.5"})
+		state := kline(0, "100", "100", "100", "100", "10")
+		c.setState(state)
+		orders, err := c.AddOrderList(newList(), state)
+		if err == nil {
+			t.Fatal("list is placed without balance")
+		}
+		checkOrder(t, orders[0], api.OrderStatus_REJECTED, "0", "")
+		checkOrder(t, orders[1], api.OrderStatus_REJECTED, "0", "")
+		checkBalance(t, c, "ETH", "0.5", "0")
+		if c.Order.Active() != 0 {
+			t.Fatal("rejected list is active")
+		}
+	})
+
+	t.Run("orders of different sides aren't listed", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"ETH": "1", "USDT": "100"})
+		state := kline(0, "100", "100", "100", "100", "10")
+		c.setState(state)
+		list := newList()
+		list.Orders[1] = &api.Order{
+			Id: "2", Symbol: "ETHUSDT", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "90", Quantity: "1",
+		}
+		if _, err := c.AddOrderList(list, state); !errors.Is(err, order.ErrInvalidList) {
+			t.Fatalf("AddOrderList() error = %v, want %v", err, order.ErrInvalidList)
+		}
+		checkBalance(t, c, "ETH", "1", "0")
+		checkBalance(t, c, "USDT", "100", "0")
+		if c.Order.Active() != 0 {
+			t.Fatal("invalid list is active")
+		}
+	})
+}
+
+func TestTimeInForce(t *testing.T) {
+	tests := []struct {
+		name     string
+		tif      api.TimeInForce
+		price    string
+		status   api.OrderStatus
+		executed string
+		usdt     string
+	}{
+		{name: "IOC fills available volume", tif: api.TimeInForce_IOC, price: "101", status: api.OrderStatus_EXPIRED, executed: "0.5", usdt: "950"},
+		{name: "IOC not crossing expires", tif: api.TimeInForce_IOC, price: "90", status: api.OrderStatus_EXPIRED, executed: "0", usdt: "1000"},
+		{name: "FOK is rejected", tif: api.TimeInForce_FOK, price: "101", status: api.OrderStatus_REJECTED, executed: "0", usdt: "1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig
+			cfg.ParticipationRate = 50
+			c := newTestClient(t, cfg, map[string]string{"USDT": "1000"})
+
+			o := place(t, c, kline(0, "100", "100", "100", "100", "1"), &api.Order{
+				Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: tt.price, Quantity: "1", TimeInForce: tt.tif,
+			})
+			checkOrder(t, o, tt.status, tt.executed, "")
+			checkBalance(t, c, "USDT", tt.usdt, "0")
+			if c.Order.Active() != 0 {
+				t.Fatal("immediate order is active")
+			}
+		})
+	}
+
+	t.Run("GTD expires", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"USDT": "1000"})
+		state := kline(1, "100", "100", "100", "100", "1")
+		c.setState(state)
+		if _, err := c.AddOrder(&api.Order{
+			Id: "1", Symbol: "ETHUSDT", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "90", Quantity: "1",
+			TimeInForce: api.TimeInForce_GTD, GoodTillTime: 60000,
+		}, state); !errors.Is(err, ErrInvalidGoodTillTime) {
+			t.Fatalf("AddOrder() error = %v, want %v", err, ErrInvalidGoodTillTime)
+		}
+
+		o := place(t, c, state, &api.Order{
+			Id: "2", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "90", Quantity: "1",
+			TimeInForce: api.TimeInForce_GTD, GoodTillTime: 3 * 60000,
+		})
+		checkBalance(t, c, "USDT", "910", "90")
+
+		replay(c, kline(2, "100", "101", "95", "100", "1"))
+		checkOrder(t, o, api.OrderStatus_NEW, "0", "")
+
+		// the order expires before the kline reaching its price
+		replay(c, kline(3, "100", "101", "85", "100", "1"))
+		checkOrder(t, o, api.OrderStatus_EXPIRED, "0", "")
+		checkBalance(t, c, "USDT", "1000", "0")
+	})
+}
+
+func TestCommission(t *testing.T) {
+	tests := []struct {
+		name        string
+		commissions []config.CommissionConfig
+		order       *api.Order
+		asset       string
+		want        string
+	}{
+		{
+			name:  "market taker",
+			order: &api.Order{Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_MARKET, Quantity: "1"},
+			asset: "ETH", want: "10.999",
+		},
+		{
+			name:  "limit maker",
+			order: &api.Order{Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "95", Quantity: "1"},
+			asset: "ETH", want: "10.9998",
+		},
+		{
+			name:  "crossing limit taker",
+			order: &api.Order{Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "101", Quantity: "1"},
+			asset: "ETH", want: "10.999",
+		},
+		{
+			name:        "maker rebate",
+			commissions: []config.CommissionConfig{{Symbol: "ETHUSDT", Maker: -0.01, Taker: 0.1}},
+			order:       &api.Order{Id: "1", Side: api.OrderSide_SELL, Type: api.OrderType_LIMIT, Price: "105", Quantity: "1"},
+			asset:       "USDT", want: "1105.0105",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig
+			cfg.MakerCommission, cfg.TakerCommission = 0.02, 0.1
+			cfg.Commissions = tt.commissions
+			c := newTestClient(t, cfg, map[string]string{"USDT": "1000", "ETH": "10"})
+
+			o := place(t, c, kline(0, "100", "100", "100", "100", "10"), tt.order)
+			replay(c, kline(1, "100", "106", "94", "100", "10"))
+			checkOrder(t, o, api.OrderStatus_FILLED, "1", "")
+			checkBalance(t, c, tt.asset, tt.want, "0")
+		})
+	}
+
+	t.Run("crossing limit maker is rejected", func(t *testing.T) {
+		c := newTestClient(t, testConfig, map[string]string{"USDT": "1000"})
+		state := kline(0, "100", "100", "100", "100", "10")
+		c.setState(state)
+		o, err := c.AddOrder(&api.Order{
+			Id: "1", Symbol: "ETHUSDT", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT_MAKER, Price: "101", Quantity: "1",
+		}, state)
+		if !errors.Is(err, ErrWouldMatch) {
+			t.Fatalf("AddOrder() error = %v, want %v", err, ErrWouldMatch)
+		}
+		checkOrder(t, o, api.OrderStatus_REJECTED, "0", "")
+		checkBalance(t, c, "USDT", "1000", "0")
+	})
+}
+
+func TestGapFill(t *testing.T) {
+	tests := []struct {
+		name  string
+		order *api.Order
+		want  string
+		usdt  string
+		eth   string
+	}{
+		{
+			name:  "limit buy",
+			order: &api.Order{Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "100", Quantity: "1"},
+			want:  "95", usdt: "905", eth: "11",
+		},
+		{
+			name:  "stop loss sell",
+			order: &api.Order{Id: "1", Side: api.OrderSide_SELL, Type: api.OrderType_STOP_LOSS, StopPrice: "98", Quantity: "1"},
+			want:  "95", usdt: "1095", eth: "9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, testConfig, map[string]string{"USDT": "1000", "ETH": "10"})
+
+			o := place(t, c, kline(0, "101", "101", "101", "101", "10"), tt.order)
+			// the kline opens below the order price and it's filled at open
+			replay(c, kline(1, "95", "97", "94", "96", "10"))
+			checkOrder(t, o, api.OrderStatus_FILLED, "1", tt.want)
+			checkBalance(t, c, "USDT", tt.usdt, "0")
+			checkBalance(t, c, "ETH", tt.eth, "0")
+		})
+	}
+}
+
+const testBook = `exchange,symbol,timestamp,local_timestamp,is_snapshot,side,price,amount
+binance,ETHUSDT,60000000,60000000,true,ask,100.5,1
+binance,ETHUSDT,60000000,60000000,true,ask,101,1
+binance,ETHUSDT,60000000,60000000,true,ask,102,2
+binance,ETHUSDT,60000000,60000000,true,bid,99.5,3
+`
+
+func TestTickFill(t *testing.T) {
+	c := newTestClient(t, testConfig, map[string]string{"USDT": "300"})
+	state := kline(0, "101", "101", "101", "101", "10")
+	first := place(t, c, state, &api.Order{Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "100", Quantity: "1"})
+	second := place(t, c, state, &api.Order{Id: "2", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "100", Quantity: "1"})
+
+	// the trade through resting orders fills them at their price up to the trade quantity
+	trade := kline(1, "95", "95", "95", "95", "1.5")
+	trade.Tick = true
+	replay(c, trade)
+	checkOrder(t, first, api.OrderStatus_FILLED, "1", "100")
+	checkOrder(t, second, api.OrderStatus_PARTIALLY_FILLED, "0.5", "100")
+	checkBalance(t, c, "USDT", "100", "50")
+	checkBalance(t, c, "ETH", "1.5", "0")
+}
+
+// newTestBook returns ETHUSDT book of testBook updates
+func newTestBook(t *testing.T) *book.Book {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "book.csv")
+	if err := os.WriteFile(file, []byte(testBook), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	updates, err := book.Load(file, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return book.New(updates)
+}
+
+func TestTakeBook(t *testing.T) {
+	cfg := testConfig
+	cfg.MakerCommission, cfg.TakerCommission = 0.02, 0.1
+	c := newTestClient(t, cfg, map[string]string{"USDT": "1000"})
+	c.books["ETHUSDT"] = newTestBook(t)
+
+	o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+		Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "101.5", Quantity: "3",
+	})
+	checkBalance(t, c, "USDT", "695.5", "304.5")
+
+	// asks up to the limit are taken at taker commission
+	replay(c, kline(1, "100", "100", "100", "100", "10"))
+	checkOrder(t, o, api.OrderStatus_PARTIALLY_FILLED, "2", "100.75")
+	checkBalance(t, c, "ETH", "1.998", "0")
+	checkBalance(t, c, "USDT", "695.5", "103")
+
+	// the rest is filled as a maker order
+	replay(c, kline(2, "101", "102", "100", "101", "10"))
+	checkOrder(t, o, api.OrderStatus_FILLED, "3", "")
+	checkBalance(t, c, "ETH", "2.9978", "0")
+	checkBalance(t, c, "USDT", "697.5", "0")
+}
+
+func TestBookMaker(t *testing.T) {
+	cfg := testConfig
+	cfg.MakerCommission, cfg.TakerCommission = 0.02, 0.1
+
+	t.Run("limit under the best ask is maker", func(t *testing.T) {
+		c := newTestClient(t, cfg, map[string]string{"USDT": "1000"})
+		c.books["ETHUSDT"] = newTestBook(t)
+
+		// the limit crosses the close but not the book
+		o := place(t, c, kline(1, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "100.2", Quantity: "1",
+		})
+		if o.Taker {
+			t.Fatal("order under the best ask is taker")
+		}
+
+		replay(c, kline(2, "100.4", "100.6", "100", "100.3", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "100.2")
+		checkBalance(t, c, "ETH", "0.9998", "0")
+	})
+
+	t.Run("taker the book doesn't fill rests on the path", func(t *testing.T) {
+		c := newTestClient(t, cfg, map[string]string{"USDT": "1000"})
+		c.books["ETHUSDT"] = newTestBook(t)
+
+		// the book is empty before its snapshot, the order crosses the close
+		o := place(t, c, kline(0, "100", "100", "100", "100", "10"), &api.Order{
+			Id: "1", Side: api.OrderSide_BUY, Type: api.OrderType_LIMIT, Price: "100.2", Quantity: "1",
+		})
+		if !o.Taker {
+			t.Fatal("order crossing the close is maker")
+		}
+
+		replay(c, kline(1, "100.4", "100.6", "100.3", "100.5", "10"))
+		checkOrder(t, o, api.OrderStatus_NEW, "0", "")
+		if o.Taker {
+			t.Fatal("order not filled on the book is taker")
+		}
+
+		replay(c, kline(2, "100.4", "100.6", "100", "100.3", "10"))
+		checkOrder(t, o, api.OrderStatus_FILLED, "1", "100.2")
+		checkBalance(t, c, "ETH", "0.9998", "0")
+	})
+}
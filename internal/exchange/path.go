@@ -0,0 +1,128 @@
+package exchange
+
+import (
+	"math/rand"
+
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/internal/parser"
+)
+
+type pathModel int8
+
+const (
+	// pathOHLC goes open -> high -> low -> close
+	pathOHLC pathModel = iota
+	// pathOLHC goes open -> low -> high -> close
+	pathOLHC
+	// pathNearest visits the extreme nearest to open first
+	pathNearest
+	// pathRandom chooses between OHLC and OLHC with a seeded random
+	pathRandom
+)
+
+var pathModels = map[string]pathModel{
+	"ohlc":    pathOHLC,
+	"olhc":    pathOLHC,
+	"nearest": pathNearest,
+	"random":  pathRandom,
+}
+
+// pricePath is a piecewise linear price movement inside a kline.
+// Positions on the path are measured as a price distance traveled from open.
+type pricePath struct {
+	points   [4]decimal.Decimal
+	distance [4]decimal.Decimal
+}
+
+func newPricePath(model pathModel, rnd *rand.Rand, state parser.ExchangeState) pricePath {
+	highFirst := true
+	switch model {
+	case pathOLHC:
+		highFirst = false
+	case pathNearest:
+		highFirst = state.High.Sub(state.Open).LessThanOrEqual(state.Open.Sub(state.Low))
+	case pathRandom:
+		highFirst = rnd.Intn(2) == 0
+	}
+
+	p := pricePath{}
+	p.points[0], p.points[3] = state.Open, state.Close
+	if highFirst {
+		p.points[1], p.points[2] = state.High, state.Low
+	} else {
+		p.points[1], p.points[2] = state.Low, state.High
+	}
+	for i := 1; i < len(p.points); i++ {
+		p.distance[i] = p.distance[i-1].Add(p.points[i].Sub(p.points[i-1]).Abs())
+	}
+
+	return p
+}
+
+// reach returns the first position not before from where price falls to level (below)
+// or rises to level (above)
+func (p pricePath) reach(level decimal.Decimal, below bool, from decimal.Decimal) (decimal.Decimal, bool) {
+	reached := func(price decimal.Decimal) bool {
+		if below {
+			return price.LessThanOrEqual(level)
+		}
+		return price.GreaterThanOrEqual(level)
+	}
+
+	for i := 0; i < len(p.points)-1; i++ {
+		if p.distance[i+1].LessThan(from) {
+			continue
+		}
+
+		start := decimal.Max(p.distance[i], from)
+		price := p.priceAt(i, start)
+		if reached(price) {
+			return start, true
+		}
+		if reached(p.points[i+1]) {
+			return start.Add(level.Sub(price).Abs()), true
+		}
+	}
+
+	return decimal.Zero, false
+}
+
+// trail moves the water mark along the path: the high-water mark when trailing below, the low-water mark otherwise.
+// It returns the first position where price retraces to the stop of the mark, the stop and the mark at that position.
+// If price doesn't retrace, it returns the mark at close.
+func (p pricePath) trail(mark decimal.Decimal, below bool, stop func(mark decimal.Decimal) decimal.Decimal) (
+	pos, level, water decimal.Decimal, ok bool,
+) {
+	extreme, reached := decimal.Min, func(price, level decimal.Decimal) bool {
+		return price.GreaterThanOrEqual(level)
+	}
+	if below {
+		extreme, reached = decimal.Max, func(price, level decimal.Decimal) bool {
+			return price.LessThanOrEqual(level)
+		}
+	}
+
+	for i := 0; i < len(p.points)-1; i++ {
+		mark = extreme(mark, p.points[i])
+		level = stop(mark)
+		if reached(p.points[i], level) {
+			return p.distance[i], level, mark, true
+		}
+		// price moving toward the stop keeps the mark
+		if reached(p.points[i+1], level) {
+			return p.distance[i].Add(p.points[i].Sub(level).Abs()), level, mark, true
+		}
+	}
+
+	return decimal.Zero, decimal.Zero, extreme(mark, p.points[len(p.points)-1]), false
+}
+
+// priceAt returns price on segment i at the position
+func (p pricePath) priceAt(i int, pos decimal.Decimal) decimal.Decimal {
+	moved := pos.Sub(p.distance[i])
+	if p.points[i+1].LessThan(p.points[i]) {
+		return p.points[i].Sub(moved)
+	}
+	return p.points[i].Add(moved)
+}
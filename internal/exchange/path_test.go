@@ -0,0 +1,101 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/internal/parser"
+)
+
+func TestPricePathReach(t *testing.T) {
+	state := parser.ExchangeState{
+		Open:  decimal.NewFromInt(100),
+		High:  decimal.NewFromInt(110),
+		Low:   decimal.NewFromInt(95),
+		Close: decimal.NewFromInt(105),
+	}
+
+	tests := []struct {
+		name  string
+		model pathModel
+		level int64
+		below bool
+		from  int64
+		want  int64
+		ok    bool
+	}{
+		{name: "open", model: pathOHLC, level: 100, below: true, want: 0, ok: true},
+		{name: "high first", model: pathOHLC, level: 108, below: false, want: 8, ok: true},
+		{name: "low after high", model: pathOHLC, level: 97, below: true, want: 23, ok: true},
+		{name: "low first", model: pathOLHC, level: 97, below: true, want: 3, ok: true},
+		{name: "high after low", model: pathOLHC, level: 108, below: false, want: 18, ok: true},
+		{name: "nearest low", model: pathNearest, level: 96, below: true, want: 4, ok: true},
+		{name: "from position", model: pathOHLC, level: 105, below: false, from: 16, want: 35, ok: true},
+		{name: "not reached", model: pathOHLC, level: 111, below: false, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := newPricePath(tt.model, nil, state)
+			got, ok := path.reach(decimal.NewFromInt(tt.level), tt.below, decimal.NewFromInt(tt.from))
+			if ok != tt.ok {
+				t.Fatalf("reach() ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && !got.Equal(decimal.NewFromInt(tt.want)) {
+				t.Errorf("reach() = %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPricePathTrail(t *testing.T) {
+	state := parser.ExchangeState{
+		Open:  decimal.NewFromInt(100),
+		High:  decimal.NewFromInt(110),
+		Low:   decimal.NewFromInt(95),
+		Close: decimal.NewFromInt(105),
+	}
+	delta := func(d int64, below bool) func(decimal.Decimal) decimal.Decimal {
+		return func(mark decimal.Decimal) decimal.Decimal {
+			if below {
+				return mark.Sub(decimal.NewFromInt(d))
+			}
+			return mark.Add(decimal.NewFromInt(d))
+		}
+	}
+
+	tests := []struct {
+		name  string
+		model pathModel
+		mark  int64
+		delta int64
+		below bool
+		want  int64
+		level int64
+		water int64
+		ok    bool
+	}{
+		{name: "sell retrace from high", model: pathOHLC, mark: 100, delta: 5, below: true, want: 15, level: 105, water: 110, ok: true},
+		{name: "sell gap at open", model: pathOHLC, mark: 120, delta: 5, below: true, want: 0, level: 115, water: 120, ok: true},
+		{name: "sell not retraced", model: pathOHLC, mark: 100, delta: 20, below: true, water: 110},
+		{name: "buy retrace from low", model: pathOLHC, mark: 100, delta: 5, want: 10, level: 100, water: 95, ok: true},
+		{name: "buy not retraced", model: pathOLHC, mark: 100, delta: 20, water: 95},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := newPricePath(tt.model, nil, state)
+			pos, level, water, ok := path.trail(decimal.NewFromInt(tt.mark), tt.below, delta(tt.delta, tt.below))
+			if ok != tt.ok {
+				t.Fatalf("trail() ok = %v, want %v", ok, tt.ok)
+			}
+			if !water.Equal(decimal.NewFromInt(tt.water)) {
+				t.Errorf("trail() mark = %s, want %d", water, tt.water)
+			}
+			if ok && (!pos.Equal(decimal.NewFromInt(tt.want)) || !level.Equal(decimal.NewFromInt(tt.level))) {
+				t.Errorf("trail() = %s at %s, want %d at %d", level, pos, tt.level, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package info
+
+import (
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+)
+
+var (
+	ErrPriceFilter  = errors.New("filter failure: PRICE_FILTER")
+	ErrLotSize      = errors.New("filter failure: LOT_SIZE")
+	ErrNotional     = errors.New("filter failure: NOTIONAL")
+	ErrPercentPrice = errors.New("filter failure: PERCENT_PRICE")
+	ErrMaxNumOrders = errors.New("filter failure: MAX_NUM_ORDERS")
+)
+
+const (
+	errNotMultiple   = "%s %s is not a multiple of %s"
+	errBelowMinimum  = "%s %s is less than minimum %s"
+	errAboveMaximum  = "%s %s is greater than maximum %s"
+	errOutsideBounds = "price %s is outside of %s - %s"
+)
+
+// PriceFilter defines price rules of the symbol. Zero values are not checked.
+type PriceFilter struct {
+	Min      decimal.Decimal
+	Max      decimal.Decimal
+	TickSize decimal.Decimal
+}
+
+func (f *PriceFilter) Check(name string, price decimal.Decimal) error {
+	if f == nil {
+		return nil
+	}
+	if !f.Min.IsZero() && price.LessThan(f.Min) {
+		return errors.Wrapf(ErrPriceFilter, errBelowMinimum, name, price, f.Min)
+	}
+	if !f.Max.IsZero() && price.GreaterThan(f.Max) {
+		return errors.Wrapf(ErrPriceFilter, errAboveMaximum, name, price, f.Max)
+	}
+	if !f.TickSize.IsZero() && !price.Sub(f.Min).Mod(f.TickSize).IsZero() {
+		return errors.Wrapf(ErrPriceFilter, errNotMultiple, name, price, f.TickSize)
+	}
+
+	return nil
+}
+
+// LotSize defines quantity rules of the symbol
+type LotSize struct {
+	Min      decimal.Decimal
+	Max      decimal.Decimal
+	StepSize decimal.Decimal
+}
+
+func (f *LotSize) Check(qty decimal.Decimal) error {
+	if f == nil {
+		return nil
+	}
+	if qty.LessThan(f.Min) {
+		return errors.Wrapf(ErrLotSize, errBelowMinimum, "quantity", qty, f.Min)
+	}
+	if !f.Max.IsZero() && qty.GreaterThan(f.Max) {
+		return errors.Wrapf(ErrLotSize, errAboveMaximum, "quantity", qty, f.Max)
+	}
+	if !f.StepSize.IsZero() && !qty.Sub(f.Min).Mod(f.StepSize).IsZero() {
+		return errors.Wrapf(ErrLotSize, errNotMultiple, "quantity", qty, f.StepSize)
+	}
+
+	return nil
+}
+
+// Notional defines order value rules of the symbol, MIN_NOTIONAL filter has no maximum
+type Notional struct {
+	Min              decimal.Decimal
+	Max              decimal.Decimal
+	ApplyMinToMarket bool
+	ApplyMaxToMarket bool
+}
+
+func (f *Notional) Check(notional decimal.Decimal, market bool) error {
+	if f == nil {
+		return nil
+	}
+	if (!market || f.ApplyMinToMarket) && notional.LessThan(f.Min) {
+		return errors.Wrapf(ErrNotional, errBelowMinimum, "notional", notional, f.Min)
+	}
+	if (!market || f.ApplyMaxToMarket) && !f.Max.IsZero() && notional.GreaterThan(f.Max) {
+		return errors.Wrapf(ErrNotional, errAboveMaximum, "notional", notional, f.Max)
+	}
+
+	return nil
+}
+
+// PercentPrice limits order price relative to the average price
+type PercentPrice struct {
+	Up   decimal.Decimal
+	Down decimal.Decimal
+}
+
+func (f *PercentPrice) Check(price, avg decimal.Decimal) error {
+	if f == nil {
+		return nil
+	}
+
+	low, high := avg.Mul(f.Down), avg.Mul(f.Up)
+	if price.LessThan(low) || (!f.Up.IsZero() && price.GreaterThan(high)) {
+		return errors.Wrapf(ErrPercentPrice, errOutsideBounds, price, low, high)
+	}
+
+	return nil
+}
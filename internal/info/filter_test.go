@@ -0,0 +1,63 @@
+package info
+
+import (
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+)
+
+const testInfo = `{"symbols":[{"symbol":"ETHUSDT","baseAsset":"ETH","quoteAsset":"USDT","filters":[
+{"filterType":"PRICE_FILTER","minPrice":"0.01000000","maxPrice":"1000000.00000000","tickSize":"0.01000000"},
+{"filterType":"LOT_SIZE","minQty":"0.00010000","maxQty":"9000.00000000","stepSize":"0.00010000"},
+{"filterType":"MIN_NOTIONAL","minNotional":"10.00000000","applyToMarket":true,"avgPriceMins":5},
+{"filterType":"PERCENT_PRICE","multiplierUp":"5","multiplierDown":"0.2","avgPriceMins":5},
+{"filterType":"MAX_NUM_ORDERS","maxNumOrders":200}]}]}`
+
+func TestSymbolFilters(t *testing.T) {
+	info, err := Parse([]byte(testInfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := info.Symbol("ethusdt")
+	if !ok {
+		t.Fatal("symbol not found")
+	}
+	if s.BaseAsset != "ETH" || s.QuoteAsset != "USDT" || s.MaxNumOrders != 200 {
+		t.Fatalf("unexpected symbol %+v", s)
+	}
+
+	avg := decimal.RequireFromString("3000")
+	tests := []struct {
+		want  error
+		name  string
+		price string
+		qty   string
+	}{
+		{name: "valid", price: "3000.01", qty: "0.0035"},
+		{name: "tick size", price: "3000.015", qty: "0.0035", want: ErrPriceFilter},
+		{name: "step size", price: "3000", qty: "0.00355", want: ErrLotSize},
+		{name: "min notional", price: "3000", qty: "0.003", want: ErrNotional},
+		{name: "percent price", price: "15000.01", qty: "0.0035", want: ErrPercentPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, qty := decimal.RequireFromString(tt.price), decimal.RequireFromString(tt.qty)
+			err := s.PriceFilter.Check("price", price)
+			if err == nil {
+				err = s.LotSize.Check(qty)
+			}
+			if err == nil {
+				err = s.Notional.Check(price.Mul(qty), false)
+			}
+			if err == nil {
+				err = s.PercentPrice.Check(price, avg)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("check error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
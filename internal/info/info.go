@@ -0,0 +1,143 @@
+package info
+
+import (
+	"os"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/goccy/go-json"
+	"github.com/xenking/decimal"
+)
+
+// Info is a parsed exchange info file
+type Info struct {
+	// Raw is the file content echoed back by GetExchangeInfo
+	Raw     map[string]interface{}
+	symbols map[string]*Symbol
+}
+
+type Symbol struct {
+	PriceFilter  *PriceFilter
+	LotSize      *LotSize
+	Notional     *Notional
+	PercentPrice *PercentPrice
+	Symbol       string
+	BaseAsset    string
+	QuoteAsset   string
+	MaxNumOrders int
+}
+
+type rawInfo struct {
+	Symbols []rawSymbol `json:"symbols"`
+}
+
+type rawSymbol struct {
+	Symbol     string      `json:"symbol"`
+	BaseAsset  string      `json:"baseAsset"`
+	QuoteAsset string      `json:"quoteAsset"`
+	Filters    []rawFilter `json:"filters"`
+}
+
+// rawFilter is a union of all supported filter fields
+type rawFilter struct {
+	MinPrice         decimal.Decimal `json:"minPrice"`
+	MaxPrice         decimal.Decimal `json:"maxPrice"`
+	TickSize         decimal.Decimal `json:"tickSize"`
+	MinQty           decimal.Decimal `json:"minQty"`
+	MaxQty           decimal.Decimal `json:"maxQty"`
+	StepSize         decimal.Decimal `json:"stepSize"`
+	MinNotional      decimal.Decimal `json:"minNotional"`
+	MaxNotional      decimal.Decimal `json:"maxNotional"`
+	MultiplierUp     decimal.Decimal `json:"multiplierUp"`
+	MultiplierDown   decimal.Decimal `json:"multiplierDown"`
+	FilterType       string          `json:"filterType"`
+	MaxNumOrders     int             `json:"maxNumOrders"`
+	ApplyToMarket    bool            `json:"applyToMarket"`
+	ApplyMinToMarket bool            `json:"applyMinToMarket"`
+	ApplyMaxToMarket bool            `json:"applyMaxToMarket"`
+}
+
+func Load(filename string) (*Info, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+func Parse(data []byte) (*Info, error) {
+	info := &Info{
+		Raw: make(map[string]interface{}),
+	}
+	if err := json.Unmarshal(data, &info.Raw); err != nil {
+		return nil, errors.Wrap(err, "decode exchange info")
+	}
+
+	var raw rawInfo
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "decode exchange info symbols")
+	}
+
+	info.symbols = make(map[string]*Symbol, len(raw.Symbols))
+	for i := range raw.Symbols {
+		s := newSymbol(&raw.Symbols[i])
+		info.symbols[s.Symbol] = s
+	}
+
+	return info, nil
+}
+
+var ErrUnknownSymbol = errors.New("unknown symbol")
+
+// Assets returns base and quote assets of the symbol listed in exchange info
+func (i *Info) Assets(symbol string) (base, quote string, err error) {
+	s, ok := i.Symbol(symbol)
+	if !ok || s.BaseAsset == "" || s.QuoteAsset == "" {
+		return "", "", errors.Wrap(ErrUnknownSymbol, strings.ToUpper(symbol))
+	}
+
+	return s.BaseAsset, s.QuoteAsset, nil
+}
+
+// Symbol returns filters of the symbol
+func (i *Info) Symbol(symbol string) (*Symbol, bool) {
+	if i == nil {
+		return nil, false
+	}
+	s, ok := i.symbols[strings.ToUpper(symbol)]
+	return s, ok
+}
+
+func newSymbol(raw *rawSymbol) *Symbol {
+	s := &Symbol{
+		Symbol:     strings.ToUpper(raw.Symbol),
+		BaseAsset:  raw.BaseAsset,
+		QuoteAsset: raw.QuoteAsset,
+	}
+
+	for i := range raw.Filters {
+		f := &raw.Filters[i]
+		switch f.FilterType {
+		case "PRICE_FILTER":
+			s.PriceFilter = &PriceFilter{Min: f.MinPrice, Max: f.MaxPrice, TickSize: f.TickSize}
+		case "LOT_SIZE":
+			s.LotSize = &LotSize{Min: f.MinQty, Max: f.MaxQty, StepSize: f.StepSize}
+		case "MIN_NOTIONAL":
+			s.Notional = &Notional{Min: f.MinNotional, ApplyMinToMarket: f.ApplyToMarket}
+		case "NOTIONAL":
+			s.Notional = &Notional{
+				Min:              f.MinNotional,
+				Max:              f.MaxNotional,
+				ApplyMinToMarket: f.ApplyMinToMarket,
+				ApplyMaxToMarket: f.ApplyMaxToMarket,
+			}
+		case "PERCENT_PRICE":
+			s.PercentPrice = &PercentPrice{Up: f.MultiplierUp, Down: f.MultiplierDown}
+		case "MAX_NUM_ORDERS":
+			s.MaxNumOrders = f.MaxNumOrders
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,23 @@
+package info
+
+import (
+	"testing"
+
+	"github.com/go-faster/errors"
+)
+
+func TestAssets(t *testing.T) {
+	info, err := Parse([]byte(testInfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, quote, err := info.Assets("ethusdt")
+	if err != nil || base != "ETH" || quote != "USDT" {
+		t.Fatalf("Assets() = %s, %s, %v, want ETH, USDT", base, quote, err)
+	}
+	// symbols missing in exchange info aren't guessed by the quote suffix
+	if _, _, err = info.Assets("BTCUSDT"); !errors.Is(err, ErrUnknownSymbol) {
+		t.Fatalf("Assets() error = %v, want %v", err, ErrUnknownSymbol)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"strings"
+	"sync/atomic"
 
 	"github.com/go-faster/errors"
 	"github.com/phuslu/log"
@@ -14,35 +15,137 @@ import (
 
 type Order struct {
 	*api.Order
-	Price           decimal.Decimal
-	Total           decimal.Decimal
-	Quantity        decimal.Decimal
+	Price    decimal.Decimal
+	Total    decimal.Decimal
+	Quantity decimal.Decimal
+	// Locked is an amount of asset reserved by the order: total for buy, quantity for sell.
+	// Market buy orders lock an estimate that is settled on fill.
+	Locked decimal.Decimal
+	// StopPrice is a trigger price of conditional orders
+	StopPrice decimal.Decimal
+	// TrailingDelta is a retracement of trailing stop orders in bips or absolute price
+	TrailingDelta decimal.Decimal
+	// TrailingMark is the high-water mark of sell or the low-water mark of buy trailing stop orders
+	TrailingMark decimal.Decimal
+	// Executed is a filled quantity of the order
+	Executed decimal.Decimal
+	// ExecutedTotal is a quote amount of all fills of the order
+	ExecutedTotal decimal.Decimal
+	// LastQuantity and LastTotal are the quantity and quote amount of the last fill
+	LastQuantity decimal.Decimal
+	LastTotal    decimal.Decimal
+	// BaseAsset and QuoteAsset are resolved from the symbol on placement
+	BaseAsset       string
+	QuoteAsset      string
 	internalOrderID uint64
+	// Triggered is set when stop price of conditional order is reached
+	Triggered bool
+	// Taker is set when the order crosses market price on placement and pays taker commission
+	Taker bool
 }
 
+// IsClosed reports whether the order reached its final status
+func (o *Order) IsClosed() bool {
+	switch o.GetStatus() {
+	case api.OrderStatus_FILLED, api.OrderStatus_CANCELED, api.OrderStatus_EXPIRED, api.OrderStatus_REJECTED:
+		return true
+	}
+	return false
+}
+
+// IsMarket reports whether the order is filled at market price
+func (o *Order) IsMarket() bool {
+	switch o.GetType() {
+	case api.OrderType_MARKET, api.OrderType_STOP_LOSS, api.OrderType_TAKE_PROFIT, api.OrderType_TRAILING_STOP:
+		return true
+	}
+	return false
+}
+
+// IsConditional reports whether the order waits for stop price to be placed
+func (o *Order) IsConditional() bool {
+	switch o.GetType() {
+	case api.OrderType_STOP_LOSS, api.OrderType_STOP_LOSS_LIMIT,
+		api.OrderType_TAKE_PROFIT, api.OrderType_TAKE_PROFIT_LIMIT, api.OrderType_TRAILING_STOP:
+		return true
+	}
+	return false
+}
+
+// IsTrailing reports whether the order is triggered by retracement from the water mark
+func (o *Order) IsTrailing() bool {
+	return o.GetType() == api.OrderType_TRAILING_STOP
+}
+
+// TrailingStop returns a trigger price of trailing stop order for the water mark
+func (o *Order) TrailingStop(mark decimal.Decimal) decimal.Decimal {
+	delta := o.TrailingDelta
+	if o.TrailingDeltaType == api.TrailingDeltaType_BIPS {
+		delta = mark.Mul(o.TrailingDelta).Div(bipsDivisor)
+	}
+	if o.GetSide() == api.OrderSide_SELL {
+		return mark.Sub(delta)
+	}
+	return mark.Add(delta)
+}
+
+// TriggersBelow reports whether conditional order is triggered when price falls to stop price.
+// Otherwise, it's triggered when price rises to stop price.
+func (o *Order) TriggersBelow() bool {
+	switch o.GetType() {
+	case api.OrderType_STOP_LOSS, api.OrderType_STOP_LOSS_LIMIT, api.OrderType_TRAILING_STOP:
+		return o.GetSide() == api.OrderSide_SELL
+	case api.OrderType_TAKE_PROFIT, api.OrderType_TAKE_PROFIT_LIMIT:
+		return o.GetSide() == api.OrderSide_BUY
+	}
+	return false
+}
+
+// AppendEncoded appends the order update frame. Fills carry the quantity and price of the last fill,
+// other updates leave them empty.
 func (o *Order) AppendEncoded(b []byte) []byte {
-	// 1641025800005|2|ccb6ulcf285m9jis89c0 = 29 raw bytes
+	// 1641025800005|2|ccb6ulcf285m9jis89c0|3690.57|1.5|0.5|3690.6
+	// = 30 raw bytes + fill price, executed quantity, last fill quantity and price
 	b = binary.BigEndian.AppendUint64(b, uint64(o.Order.TransactTime))
 	b = append(b, byte(o.Order.Status))
 	b = append(b, o.Order.Id...)
+	b = append(b, '|')
+	b = append(b, o.Order.FillPrice...)
+	b = append(b, '|')
+	b = append(b, o.Order.ExecutedQuantity...)
+	b = append(b, '|')
+	if (o.Status == api.OrderStatus_PARTIALLY_FILLED || o.Status == api.OrderStatus_FILLED) && o.LastQuantity.IsPositive() {
+		b = append(b, o.LastQuantity.String()...)
+		b = append(b, '|')
+		b = append(b, o.LastTotal.Div(o.LastQuantity).String()...)
+	} else {
+		b = append(b, '|')
+	}
 	return b
 }
 
+var bipsDivisor = decimal.NewFromInt(10000)
+
 type Tracker struct {
 	transactions chan transaction
 	signal       chan struct{}
 	log          *log.Logger
 	active       []*Order
+	// activeCount is a number of active orders readable outside of Start
+	activeCount int64
 }
 
 func New() *Tracker {
 	return &Tracker{
 		transactions: make(chan transaction, 1024),
-		signal:       make(chan struct{}, 10),
+		signal:       make(chan struct{}, 1),
 	}
 }
 
-var ErrNotFound = errors.New("order not found")
+var (
+	ErrNotFound    = errors.New("order not found")
+	ErrInvalidList = errors.New("order list must contain two orders of the same symbol and side")
+)
 
 type transactionType int8
 
@@ -53,11 +156,13 @@ const (
 	typeRemoveRange
 	typeUpdate
 	typeRange
+	typeAddList
 )
 
 type transaction struct {
 	action          func(data *Order) bool
 	id              string
+	list            []*Order
 	transactionType transactionType
 }
 
@@ -75,10 +180,7 @@ func (t *Tracker) Start(ctx context.Context) {
 				tt.action(nil)
 			case typeRemoveRange:
 				tt.action(nil)
-
-				if len(t.active) == 0 {
-					t.signal <- struct{}{}
-				}
+				t.setActive()
 			case typeAdd:
 				orderSequence++
 				order := &Order{
@@ -94,9 +196,22 @@ func (t *Tracker) Start(ctx context.Context) {
 				t.log.Trace().Str("id", order.Id).Uint64("internal", order.OrderId).Str("symbol", order.Symbol).
 					Int64("ts", order.TransactTime).Msg("order added")
 
-				if len(t.active) == 1 {
-					t.signal <- struct{}{}
+				t.setActive()
+			case typeAddList:
+				for _, order := range tt.list {
+					orderSequence++
+					order.internalOrderID = orderSequence
+					order.OrderId = orderSequence
+
+					data[order.Id] = order
+					t.active = append(t.active, order)
+
+					t.log.Trace().Str("id", order.Id).Uint64("internal", order.OrderId).Str("symbol", order.Symbol).
+						Str("list", order.OrderListId).Int64("ts", order.TransactTime).Msg("order added")
 				}
+				tt.action(nil)
+
+				t.setActive()
 			case typeCancel:
 				var order *Order
 				for i, o := range t.active {
@@ -114,9 +229,7 @@ func (t *Tracker) Start(ctx context.Context) {
 						Int64("ts", order.TransactTime).Msg("order deleted")
 				}
 
-				if len(t.active) == 0 {
-					t.signal <- struct{}{}
-				}
+				t.setActive()
 			case typeUpdate:
 				order, ok := data[tt.id]
 				tt.action(order)
@@ -147,27 +260,10 @@ func (t *Tracker) Add(order *api.Order, timestamp int64) *Order {
 			defer close(errc)
 
 			order.OrderId = o.internalOrderID
-			order.Symbol = strings.ToUpper(order.Symbol)
-			order.TransactTime = timestamp
-			order.Status = api.OrderStatus_NEW
-
-			o.Order = order
-
-			var err error
-
-			o.Price, err = decimal.NewFromString(order.GetPrice())
-			if err != nil {
+			if err := o.init(order, timestamp); err != nil {
 				errc <- err
 				return false
 			}
-			o.Quantity, err = decimal.NewFromString(order.GetQuantity())
-			if err != nil {
-				errc <- err
-				return false
-			}
-
-			o.Total = o.Price.Mul(o.Quantity)
-			o.Order.Total = o.Total.String()
 
 			newOrder = o
 
@@ -181,6 +277,78 @@ func (t *Tracker) Add(order *api.Order, timestamp int64) *Order {
 	return newOrder
 }
 
+// AddList adds orders linked into one order list. All orders are added in one transaction.
+func (t *Tracker) AddList(listID string, orders []*api.Order, timestamp int64) ([]*Order, error) {
+	if len(orders) != 2 || orders[0].GetSymbol() != orders[1].GetSymbol() || orders[0].GetSide() != orders[1].GetSide() {
+		return nil, ErrInvalidList
+	}
+	if listID == "" {
+		listID = orders[0].GetId()
+	}
+
+	list := make([]*Order, len(orders))
+	for i, order := range orders {
+		order.OrderListId = listID
+
+		list[i] = &Order{}
+		if err := list[i].init(order, timestamp); err != nil {
+			return nil, err
+		}
+	}
+
+	done := make(chan struct{})
+	t.transactions <- transaction{
+		transactionType: typeAddList,
+		list:            list,
+		action: func(_ *Order) bool {
+			close(done)
+			return true
+		},
+	}
+	<-done
+
+	return list, nil
+}
+
+func (o *Order) init(order *api.Order, timestamp int64) error {
+	order.Symbol = strings.ToUpper(order.Symbol)
+	order.TransactTime = timestamp
+	order.Status = api.OrderStatus_NEW
+
+	o.Order = order
+
+	var err error
+
+	// market orders are priced on fill
+	if !o.IsMarket() {
+		o.Price, err = decimal.NewFromString(order.GetPrice())
+		if err != nil {
+			return err
+		}
+	}
+	if o.IsTrailing() {
+		o.TrailingDelta, err = decimal.NewFromString(order.GetTrailingDelta())
+		if err != nil {
+			return err
+		}
+	} else if o.IsConditional() {
+		o.StopPrice, err = decimal.NewFromString(order.GetStopPrice())
+		if err != nil {
+			return err
+		}
+	}
+	o.Quantity, err = decimal.NewFromString(order.GetQuantity())
+	if err != nil {
+		return err
+	}
+
+	o.Total = o.Price.Mul(o.Quantity)
+	o.Order.Total = o.Total.String()
+	o.Order.ExecutedQuantity = o.Executed.String()
+
+	return nil
+}
+
 func (t *Tracker) Get(id string) *Order {
 	resp := make(chan Order)
 	t.transactions <- transaction{
@@ -273,6 +441,23 @@ func (t *Tracker) RemoveRange(orders []string) {
 	<-done
 }
 
+// setActive keeps a number of active orders and signals when orders appear or run out
+func (t *Tracker) setActive() {
+	n := int64(len(t.active))
+	if old := atomic.SwapInt64(&t.activeCount, n); (old == 0) != (n == 0) {
+		select {
+		case t.signal <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Active returns a number of active orders
+func (t *Tracker) Active() int {
+	return int(atomic.LoadInt64(&t.activeCount))
+}
+
+// Control signals that orders appear or run out, signals may be coalesced
 func (t *Tracker) Control() <-chan struct{} {
 	return t.signal
 }
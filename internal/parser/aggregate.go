@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+)
+
+// tickInterval is an interval of klines aggregated from ticks for the prices WS
+const tickInterval = int64(60000)
+
+var ErrInvalidInterval = errors.New("invalid interval")
+
+var intervalUnits = map[byte]int64{
+	's': 1000,
+	'm': 60 * 1000,
+	'h': 60 * 60 * 1000,
+	'd': 24 * 60 * 60 * 1000,
+	'w': 7 * 24 * 60 * 60 * 1000,
+}
+
+// ParseInterval parses kline interval like 5m, 1h or 1d to milliseconds
+func ParseInterval(interval string) (int64, error) {
+	if len(interval) < 2 {
+		return 0, errors.Wrap(ErrInvalidInterval, interval)
+	}
+
+	unit, ok := intervalUnits[interval[len(interval)-1]]
+	n, err := strconv.ParseInt(interval[:len(interval)-1], 10, 64)
+	if !ok || err != nil || n <= 0 {
+		return 0, errors.Wrap(ErrInvalidInterval, interval)
+	}
+
+	return n * unit, nil
+}
+
+// PriceEncoder encodes states sent to the prices WS into a reused buffer.
+// Trades are sent as klines on close, so clients receive klines regardless of the dataset.
+// Resampled klines are sent in frames starting with KlineFrame byte, price frames start with zero byte.
+// Frames of multi-symbol replays are suffixed with |SYMBOL, single symbol replays keep the plain frame.
+type PriceEncoder struct {
+	ticks   *Resampler
+	buf     []byte
+	symbols bool
+}
+
+func NewPriceEncoder(multiSymbol bool) *PriceEncoder {
+	return &PriceEncoder{
+		ticks:   NewResampler("", tickInterval, 0),
+		symbols: multiSymbol,
+	}
+}
+
+// Encode returns the encoded price of the state, it's valid until the next call.
+// It returns false for trades that don't close a kline.
+func (p *PriceEncoder) Encode(state ExchangeState) ([]byte, bool) {
+	p.buf = p.buf[:0]
+	if !state.Tick {
+		p.buf = p.appendPrice(p.buf, state)
+		return p.buf, true
+	}
+
+	p.ticks.Add(state, func(kline ExchangeState) {
+		p.buf = p.appendPrice(p.buf, kline)
+	})
+	return p.buf, len(p.buf) > 0
+}
+
+// EncodeKline returns the encoded resampled kline, it's valid until the next call
+func (p *PriceEncoder) EncodeKline(kline ExchangeState) []byte {
+	p.buf = kline.AppendEncodedKline(p.buf[:0])
+	if p.symbols {
+		p.buf = appendSymbol(p.buf, kline.Symbol)
+	}
+	return p.buf
+}
+
+func (p *PriceEncoder) appendPrice(b []byte, state ExchangeState) []byte {
+	b = state.AppendEncoded(b)
+	if p.symbols {
+		b = appendSymbol(b, state.Symbol)
+	}
+	return b
+}
+
+// Resampler aggregates states of every symbol into klines of the interval
+type Resampler struct {
+	builders map[string]*klineBuilder
+	name     string
+	interval int64
+	base     int64
+}
+
+// NewResampler returns a resampler of states with the base interval, zero base is used for ticks
+func NewResampler(name string, interval, base int64) *Resampler {
+	return &Resampler{
+		builders: make(map[string]*klineBuilder),
+		name:     name,
+		interval: interval,
+		base:     base,
+	}
+}
+
+// Add adds the state to the kline of its symbol and emits klines closed by it
+func (r *Resampler) Add(e ExchangeState, emit func(kline ExchangeState)) {
+	b, ok := r.builders[e.Symbol]
+	if !ok {
+		b = &klineBuilder{interval: r.interval, base: r.base}
+		r.builders[e.Symbol] = b
+	}
+
+	b.add(e, func(kline ExchangeState) {
+		kline.Interval = r.name
+		emit(kline)
+	})
+}
+
+// klineBuilder aggregates states into klines of the interval.
+// Kline is closed by its last state when the base interval is known, otherwise by the state of the next kline.
+type klineBuilder struct {
+	kline    ExchangeState
+	interval int64
+	base     int64
+	open     bool
+}
+
+func (b *klineBuilder) add(e ExchangeState, emit func(kline ExchangeState)) {
+	start := e.Unix - e.Unix%b.interval
+	if b.open && start < b.kline.Unix {
+		// the replay went back in time, the open kline won't be closed
+		b.open = false
+	}
+	if b.open && start == b.kline.Unix {
+		b.kline.High = decimal.Max(b.kline.High, e.High)
+		b.kline.Low = decimal.Min(b.kline.Low, e.Low)
+		b.kline.Close = e.Close
+		b.kline.Volume = b.kline.Volume.Add(e.Volume)
+		b.kline.QuoteVolume = b.kline.QuoteVolume.Add(e.QuoteVolume)
+	} else {
+		if b.open {
+			emit(b.kline)
+		}
+		b.kline = ExchangeState{
+			Open:        e.Open,
+			High:        e.High,
+			Low:         e.Low,
+			Close:       e.Close,
+			Volume:      e.Volume,
+			QuoteVolume: e.QuoteVolume,
+			Symbol:      e.Symbol,
+			Unix:        start,
+		}
+		b.open = true
+	}
+
+	if b.base > 0 && e.Unix+b.base >= start+b.interval {
+		b.open = false
+		emit(b.kline)
+	}
+}
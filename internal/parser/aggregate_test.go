@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/xenking/decimal"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		interval string
+		want     int64
+		wantErr  bool
+	}{
+		{"1m", 60000, false},
+		{"15m", 900000, false},
+		{"4h", 14400000, false},
+		{"1d", 86400000, false},
+		{"m", 0, true},
+		{"0m", 0, true},
+		{"5x", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseInterval(tt.interval)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseInterval(%s) = %d, %v, want %d", tt.interval, got, err, tt.want)
+		}
+	}
+}
+
+func TestResampleKlines(t *testing.T) {
+	const base = 60000
+	var klines []ExchangeState
+	r := NewResampler("3m", 3*base, base)
+	emit := func(kline ExchangeState) {
+		klines = append(klines, kline)
+	}
+
+	prices := []int64{10, 12, 9, 11, 13}
+	for i, p := range prices {
+		// the fourth kline is missing, so the second 3m kline is closed by the next one
+		unix := int64(i) * base
+		if i >= 3 {
+			unix += base
+		}
+		price := decimal.New(p, 0)
+		r.Add(ExchangeState{
+			Open: price, High: price, Low: price, Close: price,
+			Volume: decimal.New(1, 0), Symbol: "ETHUSDT", Unix: unix,
+		}, emit)
+	}
+
+	if len(klines) != 2 {
+		t.Fatalf("klines = %+v", klines)
+	}
+	first := klines[0]
+	if first.Interval != "3m" || first.Unix != 0 || first.Open.String() != "10" || first.High.String() != "12" ||
+		first.Low.String() != "9" || first.Close.String() != "9" || first.Volume.String() != "3" {
+		t.Errorf("first kline = %+v", first)
+	}
+	if klines[1].Unix != 3*base || klines[1].Close.String() != "13" {
+		t.Errorf("second kline = %+v", klines[1])
+	}
+}
+
+func TestPriceEncoder(t *testing.T) {
+	price := decimal.New(369009, -2)
+	kline := ExchangeState{Close: price, Symbol: "ETHUSDT", Unix: 1640995440000}
+
+	// single symbol replay keeps frames without symbol
+	b, ok := NewPriceEncoder(false).Encode(kline)
+	if !ok || string(b) != string(kline.AppendEncoded(nil)) {
+		t.Fatalf("Encode() = %q, %v", b, ok)
+	}
+
+	p := NewPriceEncoder(true)
+	b, ok = p.Encode(kline)
+	if !ok || string(b) != string(kline.AppendEncoded(nil))+"|ETHUSDT" {
+		t.Fatalf("Encode() = %q, %v", b, ok)
+	}
+
+	tick := ExchangeState{Open: price, High: price, Low: price, Close: price, Symbol: "ETHUSDT", Unix: 1640995440100, Tick: true}
+	if _, ok = p.Encode(tick); ok {
+		t.Fatal("trade is encoded before its kline is closed")
+	}
+	tick.Unix += tickInterval
+	b, ok = p.Encode(tick)
+	want := string(ExchangeState{Close: price, Unix: 1640995440000}.AppendEncoded(nil)) + "|ETHUSDT"
+	if !ok || string(b) != want {
+		t.Fatalf("Encode() = %q, %v, want %q", b, ok, want)
+	}
+}
+
+func TestEncodeKline(t *testing.T) {
+	kline := ExchangeState{
+		Open:     decimal.New(369057, -2),
+		High:     decimal.New(369103, -2),
+		Low:      decimal.New(368800, -2),
+		Close:    decimal.New(369009, -2),
+		Volume:   decimal.New(5, -1),
+		Symbol:   "ETHUSDT",
+		Interval: "5m",
+		Unix:     1640995440000,
+	}
+
+	p := NewPriceEncoder(true)
+	price, _ := p.Encode(kline)
+	if price[0] == KlineFrame {
+		t.Fatal("price frame starts with kline frame byte")
+	}
+
+	b := p.EncodeKline(kline)
+	want := "K" + string(binary.BigEndian.AppendUint64(nil, 1640995440000)) + "5m|3690.57|3691.03|3688.00|3690.09|0.5|ETHUSDT"
+	if string(b) != want {
+		t.Fatalf("EncodeKline() = %q, want %q", b, want)
+	}
+}
@@ -0,0 +1,531 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/config"
+)
+
+// Cache is a memory mapped binary columnar dataset. One read-only mapping is shared by all listeners.
+// Layout, little endian:
+//
+//	magic[8] | count u64 | modTime i64 | priceExp i32 | volumeExp i32 | quoteExp i32 | symbols u32 |
+//	symbols (len u8 | name | tick u8)... | padding to 8 bytes |
+//	unix i64[count] | open i64[count] | high i64[count] | low i64[count] | close i64[count] |
+//	volume i64[count] | quoteVolume i64[count] | symbol u16[count]
+//
+// Prices and volumes are fixed-point mantissas with the column exponent.
+// Unix column is sorted and serves as the timestamp index. Tick marks symbols of trade datasets.
+//
+// Listeners replay the cache through cursors. Pages of states behind the slowest cursor are released,
+// so resident memory is bounded by the replayed window instead of the replayed range.
+type Cache struct {
+	data        []byte
+	unix        []byte
+	open        []byte
+	high        []byte
+	low         []byte
+	close       []byte
+	volume      []byte
+	quoteVolume []byte
+	symbol      []byte
+	symbols     []string
+	ticks       []bool
+	count       int
+	modTime     int64
+	priceExp    int32
+	volumeExp   int32
+	quoteExp    int32
+	// columnsOffset is an offset of the first column in the mapping
+	columnsOffset int
+
+	mu      sync.Mutex
+	cursors map[*Cursor]struct{}
+	// released is a number of states whose pages are released
+	released int
+}
+
+// Cursor is a position of a listener in the cache, states behind all cursors aren't replayed anymore.
+// Methods of nil cursor do nothing.
+type Cursor struct {
+	cache *Cache
+	pos   int
+}
+
+const (
+	cacheMagic      = "XEMUKLN2"
+	cacheHeaderSize = 40
+	maxCacheSymbols = 1 << 16
+)
+
+var (
+	ErrInvalidCache  = errors.New("invalid dataset cache")
+	ErrFixedOverflow = errors.New("value doesn't fit fixed-point column")
+)
+
+// OpenCache maps the cache file into memory
+func OpenCache(filename string) (*Cache, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() < cacheHeaderSize {
+		return nil, ErrInvalidCache
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	// read-ahead hint, replayed pages are released behind cursors
+	_ = syscall.Madvise(data, syscall.MADV_SEQUENTIAL)
+
+	c := &Cache{data: data, cursors: make(map[*Cursor]struct{})}
+	if err = c.parse(); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Cache) parse() error {
+	if string(c.data[:8]) != cacheMagic {
+		return ErrInvalidCache
+	}
+
+	le := binary.LittleEndian
+	c.count = int(le.Uint64(c.data[8:]))
+	c.modTime = int64(le.Uint64(c.data[16:]))
+	c.priceExp = int32(le.Uint32(c.data[24:]))
+	c.volumeExp = int32(le.Uint32(c.data[28:]))
+	c.quoteExp = int32(le.Uint32(c.data[32:]))
+	symbols := int(le.Uint32(c.data[36:]))
+
+	pos := cacheHeaderSize
+	c.symbols = make([]string, symbols)
+	c.ticks = make([]bool, symbols)
+	for i := range c.symbols {
+		if pos >= len(c.data) || pos+2+int(c.data[pos]) > len(c.data) {
+			return ErrInvalidCache
+		}
+		n := int(c.data[pos])
+		c.symbols[i] = string(c.data[pos+1 : pos+1+n])
+		c.ticks[i] = c.data[pos+1+n] == 1
+		pos += 2 + n
+	}
+	pos = align8(pos)
+
+	if len(c.data) != pos+c.count*(7*8+2) {
+		return ErrInvalidCache
+	}
+
+	c.columnsOffset = pos
+	columns := []*[]byte{&c.unix, &c.open, &c.high, &c.low, &c.close, &c.volume, &c.quoteVolume}
+	for _, col := range columns {
+		*col = c.data[pos : pos+c.count*8]
+		pos += c.count * 8
+	}
+	c.symbol = c.data[pos:]
+
+	return nil
+}
+
+func (c *Cache) Close() error {
+	return syscall.Munmap(c.data)
+}
+
+// releaseStates is a number of states a cursor moves before pages behind it are released
+const releaseStates = 4096
+
+// NewCursor returns a cursor at the position, states behind it may be released until it's closed
+func (c *Cache) NewCursor(pos int) *Cursor {
+	cur := &Cursor{cache: c, pos: pos}
+	c.mu.Lock()
+	c.cursors[cur] = struct{}{}
+	if pos < c.released {
+		c.released = pos
+	}
+	c.mu.Unlock()
+	return cur
+}
+
+// Move sets the cursor position, pages of states behind the slowest cursor are released once it moved far enough.
+// States behind a cursor moved back are read from the file again.
+func (cur *Cursor) Move(pos int) {
+	if cur == nil || (pos >= cur.pos && pos-cur.pos < releaseStates) {
+		return
+	}
+
+	c := cur.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cur.pos = pos
+	if pos < c.released {
+		c.released = pos
+	}
+	c.releaseBehind()
+}
+
+// Close removes the cursor, its states are released if no other cursor is behind them
+func (cur *Cursor) Close() {
+	if cur == nil {
+		return
+	}
+
+	c := cur.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cursors, cur)
+	c.releaseBehind()
+}
+
+// releaseBehind releases pages of states behind the slowest cursor, all states without cursors.
+// Pages partly holding states ahead of it are kept.
+func (c *Cache) releaseBehind() {
+	slowest := c.count
+	for cur := range c.cursors {
+		if cur.pos < slowest {
+			slowest = cur.pos
+		}
+	}
+	if slowest <= c.released {
+		return
+	}
+
+	page := os.Getpagesize()
+	start := c.columnsOffset
+	for _, width := range []int{8, 8, 8, 8, 8, 8, 8, 2} {
+		lo := alignDown(start+c.released*width, page)
+		if lo < start {
+			lo = alignDown(start, page) + page
+		}
+		hi := alignDown(start+slowest*width, page)
+		if hi > lo {
+			_ = syscall.Madvise(c.data[lo:hi], syscall.MADV_DONTNEED)
+		}
+		start += c.count * width
+	}
+	c.released = slowest
+}
+
+// Symbols returns symbols of cached states, states without symbol have empty one
+func (c *Cache) Symbols() []string {
+	return c.symbols
+}
+
+// Len returns a number of states in the cache
+func (c *Cache) Len() int {
+	return c.count
+}
+
+// ModTime returns a modification time of the source datasets the cache is built from
+func (c *Cache) ModTime() int64 {
+	return c.modTime
+}
+
+// Unix returns a timestamp of the i-th state
+func (c *Cache) Unix(i int) int64 {
+	return int64(binary.LittleEndian.Uint64(c.unix[i*8:]))
+}
+
+// Search returns an index of the first state not before the timestamp
+func (c *Cache) Search(unix int64) int {
+	return sort.Search(c.count, func(i int) bool {
+		return c.Unix(i) >= unix
+	})
+}
+
+// State decodes the i-th state
+func (c *Cache) State(i int) ExchangeState {
+	le := binary.LittleEndian
+	off := i * 8
+	symbol := le.Uint16(c.symbol[i*2:])
+	return ExchangeState{
+		Open:        fixedDecimal(int64(le.Uint64(c.open[off:])), c.priceExp),
+		High:        fixedDecimal(int64(le.Uint64(c.high[off:])), c.priceExp),
+		Low:         fixedDecimal(int64(le.Uint64(c.low[off:])), c.priceExp),
+		Close:       fixedDecimal(int64(le.Uint64(c.close[off:])), c.priceExp),
+		Volume:      fixedDecimal(int64(le.Uint64(c.volume[off:])), c.volumeExp),
+		QuoteVolume: fixedDecimal(int64(le.Uint64(c.quoteVolume[off:])), c.quoteExp),
+		Symbol:      c.symbols[symbol],
+		Tick:        c.ticks[symbol],
+		Unix:        c.Unix(i),
+	}
+}
+
+// WriteCache writes states ordered by time to the cache file
+func WriteCache(filename string, data []ExchangeState, modTime int64) error {
+	var priceExp, volumeExp, quoteExp int32
+	symbols := newSymbolTable()
+	for i := range data {
+		e := &data[i]
+		priceExp = minExponent(priceExp, e.Open, e.High, e.Low, e.Close)
+		volumeExp = minExponent(volumeExp, e.Volume)
+		quoteExp = minExponent(quoteExp, e.QuoteVolume)
+		if _, err := symbols.id(e.Symbol, e.Tick); err != nil {
+			return err
+		}
+	}
+
+	w, err := newCacheWriter(filename, cacheHeader{
+		count: len(data), modTime: modTime, priceExp: priceExp, volumeExp: volumeExp, quoteExp: quoteExp,
+	}, symbols)
+	if err != nil {
+		return err
+	}
+	defer w.abort()
+
+	for i := range data {
+		if err = w.add(data[i]); err != nil {
+			return err
+		}
+	}
+
+	return w.commit()
+}
+
+// cacheHeader is a header of the cache file, the number of states and column exponents are known before states are written
+type cacheHeader struct {
+	count     int
+	modTime   int64
+	priceExp  int32
+	volumeExp int32
+	quoteExp  int32
+}
+
+// cacheWriter writes states ordered by time to columns of a temporary cache file, the file replaces the cache on commit
+type cacheWriter struct {
+	f        *os.File
+	filename string
+	header   cacheHeader
+	symbols  *symbolTable
+	columns  [8]*bufio.Writer
+	written  int
+	buf      [8]byte
+}
+
+// columnWriter writes a column of the cache file from its offset
+type columnWriter struct {
+	f   *os.File
+	off int64
+}
+
+func (w *columnWriter) Write(p []byte) (int, error) {
+	n, err := w.f.WriteAt(p, w.off)
+	w.off += int64(n)
+	return n, err
+}
+
+func newCacheWriter(filename string, header cacheHeader, symbols *symbolTable) (*cacheWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(filename + ".tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	le := binary.LittleEndian
+	b := make([]byte, cacheHeaderSize, align8(cacheHeaderSize+len(symbols.names)*257))
+	copy(b, cacheMagic)
+	le.PutUint64(b[8:], uint64(header.count))
+	le.PutUint64(b[16:], uint64(header.modTime))
+	le.PutUint32(b[24:], uint32(header.priceExp))
+	le.PutUint32(b[28:], uint32(header.volumeExp))
+	le.PutUint32(b[32:], uint32(header.quoteExp))
+	le.PutUint32(b[36:], uint32(len(symbols.names)))
+	for i, name := range symbols.names {
+		b = append(b, byte(len(name)))
+		b = append(b, name...)
+		if symbols.ticks[i] {
+			b = append(b, 1)
+		} else {
+			b = append(b, 0)
+		}
+	}
+	for len(b) != align8(len(b)) {
+		b = append(b, 0)
+	}
+
+	w := &cacheWriter{f: f, filename: filename, header: header, symbols: symbols}
+	if _, err = f.Write(b); err != nil {
+		w.abort()
+		return nil, err
+	}
+
+	off := int64(len(b))
+	for i := range w.columns {
+		w.columns[i] = bufio.NewWriter(&columnWriter{f: f, off: off})
+		off += int64(header.count) * 8
+	}
+
+	return w, nil
+}
+
+// add writes the next state to the columns
+func (w *cacheWriter) add(e ExchangeState) error {
+	if w.written == w.header.count {
+		return errors.Wrap(ErrInvalidCache, "more states than the header counts")
+	}
+	symbol, err := w.symbols.id(e.Symbol, e.Tick)
+	if err != nil {
+		return err
+	}
+
+	le := binary.LittleEndian
+	le.PutUint64(w.buf[:], uint64(e.Unix))
+	_, _ = w.columns[0].Write(w.buf[:])
+
+	values := [...]struct {
+		value decimal.Decimal
+		exp   int32
+	}{
+		{e.Open, w.header.priceExp},
+		{e.High, w.header.priceExp},
+		{e.Low, w.header.priceExp},
+		{e.Close, w.header.priceExp},
+		{e.Volume, w.header.volumeExp},
+		{e.QuoteVolume, w.header.quoteExp},
+	}
+	for i, v := range values {
+		m, err := fixedMantissa(v.value, v.exp)
+		if err != nil {
+			return errors.Wrapf(err, "state %d", e.Unix)
+		}
+		le.PutUint64(w.buf[:], uint64(m))
+		_, _ = w.columns[i+1].Write(w.buf[:])
+	}
+
+	le.PutUint16(w.buf[:], symbol)
+	_, _ = w.columns[7].Write(w.buf[:2])
+	w.written++
+
+	return nil
+}
+
+// commit flushes columns and replaces the cache file
+func (w *cacheWriter) commit() error {
+	if w.written != w.header.count {
+		return errors.Wrapf(ErrInvalidCache, "%d states written, header counts %d", w.written, w.header.count)
+	}
+	for _, col := range w.columns {
+		if err := col.Flush(); err != nil {
+			return err
+		}
+	}
+	if err := w.f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(w.f.Name(), w.filename)
+}
+
+// abort removes the temporary file, it does nothing after commit
+func (w *cacheWriter) abort() {
+	_ = w.f.Close()
+	_ = os.Remove(w.filename + ".tmp")
+}
+
+// cachePath returns a cache file of the datasets validated with policies in the cache directory
+func cachePath(dir string, datasets []config.DatasetConfig, validation config.ValidationConfig) string {
+	h := fnv.New64a()
+	for _, policy := range []string{validation.Gaps, validation.Duplicates, validation.OHLC, validation.ZeroVolume} {
+		_, _ = h.Write([]byte(policy))
+		_, _ = h.Write([]byte{0})
+	}
+	for _, dataset := range datasets {
+		_, _ = h.Write([]byte(dataset.Symbol))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(dataset.File))
+		_, _ = h.Write([]byte{0})
+	}
+	return filepath.Join(dir, strconv.FormatUint(h.Sum64(), 16)+".bin")
+}
+
+// datasetsModTime returns the latest modification time of dataset files and directories with their entries
+func datasetsModTime(datasets []config.DatasetConfig) (int64, error) {
+	var latest int64
+	for _, dataset := range datasets {
+		fi, err := os.Stat(dataset.File)
+		if err != nil {
+			return 0, err
+		}
+		latest = maxInt64(latest, fi.ModTime().UnixNano())
+		if !fi.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(dataset.File)
+		if err != nil {
+			return 0, err
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				return 0, err
+			}
+			latest = maxInt64(latest, info.ModTime().UnixNano())
+		}
+	}
+
+	return latest, nil
+}
+
+func fixedMantissa(d decimal.Decimal, exp int32) (int64, error) {
+	m := d.Shift(-exp).BigInt()
+	if !m.IsInt64() {
+		return 0, errors.Wrap(ErrFixedOverflow, d.String())
+	}
+	return m.Int64(), nil
+}
+
+// fixedDecimal removes trailing zeros of the mantissa, so decoded values equal parsed ones
+func fixedDecimal(m int64, exp int32) decimal.Decimal {
+	for m != 0 && exp < 0 && m%10 == 0 {
+		m /= 10
+		exp++
+	}
+	return decimal.New(m, exp)
+}
+
+func minExponent(exp int32, values ...decimal.Decimal) int32 {
+	for _, v := range values {
+		if v.Exponent() < exp {
+			exp = v.Exponent()
+		}
+	}
+	return exp
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func align8(n int) int {
+	return (n + 7) &^ 7
+}
+
+func alignDown(n, align int) int {
+	return n - n%align
+}
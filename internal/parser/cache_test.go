@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xenking/decimal"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	data := []ExchangeState{
+		{Symbol: "ETHUSDT", Unix: 1640995200000, Open: decimal.RequireFromString("3676.22"),
+			High: decimal.RequireFromString("3680"), Low: decimal.RequireFromString("3676.21"),
+			Close: decimal.RequireFromString("3679.98"), Volume: decimal.RequireFromString("194.0107"),
+			QuoteVolume: decimal.RequireFromString("713695.948937")},
+		{Symbol: "SHIBUSDT", Unix: 1640995200000, Open: decimal.RequireFromString("0.00003372"),
+			High: decimal.RequireFromString("0.00003379"), Low: decimal.RequireFromString("0.0000337"),
+			Close: decimal.RequireFromString("0.00003376"), Volume: decimal.RequireFromString("12345678901"),
+			QuoteVolume: decimal.RequireFromString("416.77")},
+		{Symbol: "ETHUSDT", Unix: 1640995260000, Open: decimal.RequireFromString("3679.99"),
+			High: decimal.RequireFromString("3684.57"), Low: decimal.RequireFromString("3679.98"),
+			Close: decimal.RequireFromString("3684"), Volume: decimal.RequireFromString("182.7538"),
+			QuoteVolume: decimal.RequireFromString("672857.353302")},
+	}
+
+	file := filepath.Join(t.TempDir(), "cache.bin")
+	if err := WriteCache(file, data, 42); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := OpenCache(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if c.Len() != len(data) || c.ModTime() != 42 {
+		t.Fatalf("cache has %d states modified at %d, want %d at 42", c.Len(), c.ModTime(), len(data))
+	}
+
+	for i, want := range data {
+		got := c.State(i)
+		if got.Symbol != want.Symbol || got.Unix != want.Unix || !got.Open.Equal(want.Open) ||
+			!got.High.Equal(want.High) || !got.Low.Equal(want.Low) || !got.Close.Equal(want.Close) ||
+			!got.Volume.Equal(want.Volume) || !got.QuoteVolume.Equal(want.QuoteVolume) {
+			t.Errorf("state %d = %+v, want %+v", i, got, want)
+		}
+		if got.Close.String() != want.Close.String() {
+			t.Errorf("state %d close = %s, want %s", i, got.Close, want.Close)
+		}
+	}
+
+	if idx := c.Search(1640995230000); idx != 2 {
+		t.Errorf("Search() = %d, want 2", idx)
+	}
+}
+
+func TestCacheCursors(t *testing.T) {
+	data := make([]ExchangeState, 20000)
+	for i := range data {
+		data[i] = ExchangeState{Symbol: "ETHUSDT", Unix: int64(i) * 60000, Close: decimal.New(int64(i), -2)}
+	}
+	file := filepath.Join(t.TempDir(), "cache.bin")
+	if err := WriteCache(file, data, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := OpenCache(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	fast, slow := c.NewCursor(0), c.NewCursor(0)
+	fast.Move(10000)
+	if c.released != 0 {
+		t.Fatalf("released %d states behind the slowest cursor at 0", c.released)
+	}
+	// small moves don't release pages
+	slow.Move(1000)
+	if c.released != 0 {
+		t.Fatalf("released %d states after a small move", c.released)
+	}
+	slow.Move(8000)
+	if c.released != 8000 {
+		t.Fatalf("released %d states, want 8000", c.released)
+	}
+	slow.Close()
+	if c.released != 10000 {
+		t.Fatalf("released %d states after the slow cursor is closed, want 10000", c.released)
+	}
+
+	// released states are read from the file again
+	back := c.NewCursor(10)
+	if c.released != 10 {
+		t.Fatalf("released %d states after a cursor behind them, want 10", c.released)
+	}
+	if e := c.State(5000); e.Unix != 5000*60000 || e.Close.String() != "50" {
+		t.Errorf("released state = %+v", e)
+	}
+	back.Close()
+	fast.Close()
+	if c.released != c.Len() {
+		t.Errorf("released %d states without cursors, want %d", c.released, c.Len())
+	}
+}
@@ -0,0 +1,276 @@
+package parser
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/goccy/go-json"
+	"github.com/xenking/decimal"
+)
+
+const (
+	yearMilliseconds = 365 * 24 * 60 * 60 * 1000
+	// generatorSteps is a number of price steps inside a kline forming its high and low
+	generatorSteps = 10
+	volumeDecimals = 3
+	// generatorExt is an extension of generator spec files used as datasets
+	generatorExt = ".gen.json"
+)
+
+var ErrInvalidGenerator = errors.New("invalid generator")
+
+// GeneratorSpec is a model of synthetic klines: geometric brownian motion with optional jumps,
+// regime switching and GARCH(1,1) volatility clustering. Drift, volatility and jump intensity are annual.
+type GeneratorSpec struct {
+	Symbol   string  `json:"symbol"`
+	Seed     int64   `json:"seed"`
+	Start    int64   `json:"start"`
+	Count    int     `json:"count"`
+	Interval string  `json:"interval"`
+	Price    float64 `json:"price"`
+	// Decimals is a price precision
+	Decimals   int32   `json:"decimals"`
+	Drift      float64 `json:"drift"`
+	Volatility float64 `json:"volatility"`
+	// Volume is a mean base volume of a kline
+	Volume float64 `json:"volume"`
+	// JumpIntensity is an expected number of jumps per year, jump log returns are normal with JumpMean and JumpVolatility
+	JumpIntensity  float64 `json:"jump_intensity"`
+	JumpMean       float64 `json:"jump_mean"`
+	JumpVolatility float64 `json:"jump_volatility"`
+	// ClusteringAlpha and ClusteringBeta are GARCH(1,1) weights of the last shock and variance, zero disables clustering
+	ClusteringAlpha float64 `json:"clustering_alpha"`
+	ClusteringBeta  float64 `json:"clustering_beta"`
+	// Regimes replace drift and volatility, a regime lasts Duration on average before switching to a random other one
+	Regimes []RegimeSpec `json:"regimes"`
+}
+
+type RegimeSpec struct {
+	Drift      float64 `json:"drift"`
+	Volatility float64 `json:"volatility"`
+	Duration   string  `json:"duration"`
+}
+
+// generator simulates log returns of the price step by step
+type generator struct {
+	spec       GeneratorSpec
+	interval   int64
+	durations  []int64
+	rnd        *rand.Rand
+	regime     int
+	drift      float64
+	volatility float64
+	// variance and shock of the last step are used by volatility clustering
+	variance float64
+	shock    float64
+	price    float64
+	// dt is a step in years
+	dt float64
+}
+
+// defaultGenerator is a day of 1m klines of a volatile market without trend
+var defaultGenerator = GeneratorSpec{
+	Seed:       1,
+	Start:      1640995200000,
+	Count:      1440,
+	Interval:   "1m",
+	Price:      100,
+	Decimals:   2,
+	Volatility: 0.8,
+	Volume:     100,
+}
+
+// isGenerator reports whether the file is a generator spec
+func isGenerator(file string) bool {
+	return strings.HasSuffix(file, generatorExt)
+}
+
+// scanGenerator sends klines generated by the spec file. Symbol overrides the symbol of the spec.
+func scanGenerator(file, symbol string, emit func(e ExchangeState) error) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	spec := defaultGenerator
+	if err = json.Unmarshal(data, &spec); err != nil {
+		return errors.Wrap(ErrInvalidGenerator, err.Error())
+	}
+	if symbol != "" {
+		spec.Symbol = symbol
+	}
+
+	return generate(spec, emit)
+}
+
+// Generate returns klines of the spec, the same seed generates the same klines
+func Generate(spec GeneratorSpec) ([]ExchangeState, error) {
+	var data []ExchangeState
+	err := generate(spec, func(e ExchangeState) error {
+		data = append(data, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// generate sends klines of the spec one by one
+func generate(spec GeneratorSpec, emit func(e ExchangeState) error) error {
+	g := &generator{spec: spec}
+	if err := g.init(); err != nil {
+		return err
+	}
+
+	symbol := parseSymbol([]byte(spec.Symbol))
+	for i := 0; i < spec.Count; i++ {
+		e := g.kline()
+		e.Symbol = symbol
+		e.Unix = spec.Start + int64(i)*g.interval
+		if err := emit(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) init() error {
+	var err error
+	if g.interval, err = ParseInterval(g.spec.Interval); err != nil {
+		return errors.Wrap(ErrInvalidGenerator, err.Error())
+	}
+	for _, r := range g.spec.Regimes {
+		duration, err := ParseInterval(r.Duration)
+		if err != nil {
+			return errors.Wrap(ErrInvalidGenerator, err.Error())
+		}
+		g.durations = append(g.durations, duration)
+	}
+	if err = checkGenerator(g.spec); err != nil {
+		return err
+	}
+
+	g.rnd = rand.New(rand.NewSource(g.spec.Seed)) //nolint:gosec
+	g.drift, g.volatility = g.spec.Drift, g.spec.Volatility
+	if len(g.spec.Regimes) > 0 {
+		g.drift, g.volatility = g.spec.Regimes[0].Drift, g.spec.Regimes[0].Volatility
+	}
+	g.price = g.spec.Price
+	g.dt = float64(g.interval) / generatorSteps / yearMilliseconds
+	g.variance = g.volatility * g.volatility * g.dt
+
+	return nil
+}
+
+func checkGenerator(spec GeneratorSpec) error {
+	switch {
+	case spec.Count <= 0:
+		return errors.Wrap(ErrInvalidGenerator, "count must be positive")
+	case spec.Price <= 0:
+		return errors.Wrap(ErrInvalidGenerator, "price must be positive")
+	case spec.Decimals < 0 || spec.Decimals > 18:
+		return errors.Wrap(ErrInvalidGenerator, "decimals must be between 0 and 18")
+	case spec.Volatility < 0 || spec.Volume < 0 || spec.JumpIntensity < 0 || spec.JumpVolatility < 0:
+		return errors.Wrap(ErrInvalidGenerator, "volatility, volume and jump intensity can't be negative")
+	case spec.ClusteringAlpha < 0 || spec.ClusteringBeta < 0 || spec.ClusteringAlpha+spec.ClusteringBeta >= 1:
+		return errors.Wrap(ErrInvalidGenerator, "clustering weights must be non-negative with a sum below 1")
+	}
+	for _, r := range spec.Regimes {
+		if r.Volatility < 0 {
+			return errors.Wrap(ErrInvalidGenerator, "regime volatility can't be negative")
+		}
+	}
+	return nil
+}
+
+func (g *generator) kline() ExchangeState {
+	g.switchRegime()
+
+	open := g.price
+	high, low := open, open
+	for i := 0; i < generatorSteps; i++ {
+		g.step()
+		high = math.Max(high, g.price)
+		low = math.Min(low, g.price)
+	}
+
+	// volume grows with the price move and has log-normal noise with mean 1
+	volume := g.spec.Volume * math.Exp(0.5*g.rnd.NormFloat64()-0.125)
+	if expected := g.volatility * math.Sqrt(g.dt*generatorSteps); expected > 0 {
+		volume *= 1 + math.Abs(math.Log(g.price/open))/expected
+	}
+
+	e := ExchangeState{
+		Open:   g.round(open),
+		High:   g.round(high),
+		Low:    g.round(low),
+		Close:  g.round(g.price),
+		Volume: decimal.NewFromFloat(volume).Round(volumeDecimals),
+	}
+	e.QuoteVolume = e.Volume.Mul(e.Close).Round(g.spec.Decimals + volumeDecimals)
+
+	return e
+}
+
+// step moves the price by a log return of drift, diffusion with clustered variance and jumps
+func (g *generator) step() {
+	variance := g.volatility * g.volatility * g.dt
+	if g.spec.ClusteringAlpha > 0 || g.spec.ClusteringBeta > 0 {
+		variance = (1-g.spec.ClusteringAlpha-g.spec.ClusteringBeta)*variance +
+			g.spec.ClusteringAlpha*g.shock*g.shock + g.spec.ClusteringBeta*g.variance
+	}
+	g.variance = variance
+	g.shock = math.Sqrt(variance) * g.rnd.NormFloat64()
+
+	r := g.drift*g.dt - variance/2 + g.shock
+	if g.spec.JumpIntensity > 0 {
+		for n := g.poisson(g.spec.JumpIntensity * g.dt); n > 0; n-- {
+			r += g.spec.JumpMean + g.spec.JumpVolatility*g.rnd.NormFloat64()
+		}
+	}
+
+	g.price *= math.Exp(r)
+}
+
+// switchRegime switches to a random other regime with a probability of the kline interval to the regime duration
+func (g *generator) switchRegime() {
+	if len(g.spec.Regimes) < 2 {
+		return
+	}
+
+	p := float64(g.interval) / float64(g.durations[g.regime])
+	if g.rnd.Float64() >= p {
+		return
+	}
+
+	next := g.rnd.Intn(len(g.spec.Regimes) - 1)
+	if next >= g.regime {
+		next++
+	}
+	g.regime = next
+	g.drift, g.volatility = g.spec.Regimes[next].Drift, g.spec.Regimes[next].Volatility
+}
+
+// poisson returns a number of events with the expected number lambda
+func (g *generator) poisson(lambda float64) int {
+	limit := math.Exp(-lambda)
+	n := 0
+	for p := g.rnd.Float64(); p > limit; p *= g.rnd.Float64() {
+		n++
+	}
+	return n
+}
+
+// round rounds the price to the precision keeping it above zero
+func (g *generator) round(price float64) decimal.Decimal {
+	d := decimal.NewFromFloat(price).Round(g.spec.Decimals)
+	if tick := decimal.New(1, -g.spec.Decimals); d.LessThan(tick) {
+		return tick
+	}
+	return d
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/exchange-emulator/config"
+)
+
+func TestGenerate(t *testing.T) {
+	spec := defaultGenerator
+	spec.Symbol = "SYN/USDT"
+	spec.Seed = 42
+	spec.JumpIntensity = 1000
+	spec.JumpMean = -0.01
+	spec.JumpVolatility = 0.02
+	spec.ClusteringAlpha = 0.1
+	spec.ClusteringBeta = 0.85
+	spec.Regimes = []RegimeSpec{{Volatility: 0.3, Duration: "1h"}, {Drift: -5, Volatility: 2, Duration: "15m"}}
+
+	data, err := Generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != spec.Count {
+		t.Fatalf("len = %d, want %d", len(data), spec.Count)
+	}
+
+	var report Report
+	if _, err = validate(data, config.ValidationConfig{
+		Gaps: PolicyFail, Duplicates: PolicyFail, OHLC: PolicyFail, ZeroVolume: PolicyFail,
+	}, &report); err != nil {
+		t.Fatalf("generated klines are invalid: %v", err)
+	}
+	if data[0].Symbol != "SYNUSDT" || data[1].Unix-data[0].Unix != 60000 {
+		t.Errorf("first klines = %+v", data[:2])
+	}
+
+	again, err := Generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		if !data[i].Close.Equal(again[i].Close) || !data[i].Volume.Equal(again[i].Volume) {
+			t.Fatalf("kline %d differs with the same seed: %+v and %+v", i, data[i], again[i])
+		}
+	}
+
+	spec.Seed++
+	other, err := Generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other[len(other)-1].Close.Equal(data[len(data)-1].Close) {
+		t.Error("klines don't depend on the seed")
+	}
+}
+
+func TestLoadGenerator(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "crash"+generatorExt)
+	spec := `{"symbol":"CRASHUSDT","count":10,"interval":"5m","regimes":[{"drift":-3,"volatility":1.5,"duration":"2h"}]}`
+	if err := os.WriteFile(file, []byte(spec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := collect(func(emit func(e ExchangeState) error) error {
+		return scan(file, "ETHUSDT", emit)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 10 || data[0].Symbol != "ETHUSDT" || data[0].Unix != defaultGenerator.Start ||
+		data[1].Unix-data[0].Unix != 300000 {
+		t.Errorf("klines = %+v", data)
+	}
+
+	if err = os.WriteFile(file, []byte(`{"clustering_alpha":0.5,"clustering_beta":0.5}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = scan(file, "", func(ExchangeState) error { return nil }); !errors.Is(err, ErrInvalidGenerator) {
+		t.Fatalf("scan() error = %v, want %v", err, ErrInvalidGenerator)
+	}
+}
@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/exchange-emulator/pkg/utils"
+)
+
+// Binance kline archive columns:
+// open time, open, high, low, close, volume, close time, quote volume, trades,
+// taker buy volume, taker buy quote volume, ignore
+const (
+	klineOpenTime = iota
+	klineOpen
+	klineHigh
+	klineLow
+	klineClose
+	klineVolume
+	klineCloseTime
+	klineQuoteVolume
+	klineColumns
+)
+
+// microsecondsThreshold separates microsecond timestamps of Binance archives since 2025 from millisecond ones
+const microsecondsThreshold = 1e14
+
+var ErrInvalidKline = errors.New("invalid kline")
+
+// errStopScan stops scanning of a dataset when the rest of it isn't needed
+var errStopScan = errors.New("stop scan")
+
+// archive is a Binance archive of the directory with its first timestamp
+type archive struct {
+	file  string
+	first int64
+	size  int64
+}
+
+// scanDir sends states of Binance archives of the directory ordered by time.
+// Daily and monthly archives may overlap, states already sent from earlier archives are dropped.
+func scanDir(dir, symbol string, emit func(e ExchangeState) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var archives []archive
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".zip" && ext != ".csv") {
+			continue
+		}
+
+		a := archive{file: filepath.Join(dir, entry.Name())}
+		found := false
+		err = scanFile(a.file, symbol, func(e ExchangeState) error {
+			a.first, found = e.Unix, true
+			return errStopScan
+		})
+		if err != nil && !errors.Is(err, errStopScan) {
+			return err
+		}
+		if !found {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		a.size = info.Size()
+		archives = append(archives, a)
+	}
+
+	// monthly archive is larger than daily ones and goes before them in the same month
+	sort.SliceStable(archives, func(i, j int) bool {
+		if archives[i].first == archives[j].first {
+			return archives[i].size > archives[j].size
+		}
+		return archives[i].first < archives[j].first
+	})
+
+	var (
+		last int64
+		sent bool
+	)
+	for _, a := range archives {
+		overlaps := sent
+		err = scanFile(a.file, symbol, func(e ExchangeState) error {
+			if overlaps && e.Unix <= last {
+				return nil
+			}
+			overlaps = false
+			last, sent = e.Unix, true
+			return emit(e)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanFile sends states of Binance kline, aggTrades or trades zip or csv archive.
+// Symbol is taken from the file name if it's empty.
+func scanFile(file, symbol string, emit func(e ExchangeState) error) error {
+	if symbol == "" {
+		symbol = archiveSymbol(file)
+	}
+	parse := archiveParser(file)
+
+	if filepath.Ext(file) != ".zip" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return scanArchive(f, symbol, parse, emit)
+	}
+
+	r, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, zf := range r.File {
+		if filepath.Ext(zf.Name) != ".csv" {
+			continue
+		}
+
+		f, err := zf.Open()
+		if err != nil {
+			return err
+		}
+
+		err = scanArchive(f, symbol, parse, emit)
+		f.Close()
+		if err != nil {
+			return errors.Wrap(err, zf.Name)
+		}
+	}
+
+	return nil
+}
+
+// scanArchive sends states of Binance archive rows skipping the header of newer archives
+func scanArchive(r io.Reader, symbol string, parse func(row []byte) (ExchangeState, error),
+	emit func(e ExchangeState) error,
+) error {
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		row := bytes.TrimRight(sc.Bytes(), "\r")
+		if len(row) == 0 || row[0] < '0' || row[0] > '9' {
+			continue
+		}
+
+		e, err := parse(row)
+		if err != nil {
+			return errors.Wrapf(err, "line %d", line)
+		}
+		e.Symbol = symbol
+		if err = emit(e); err != nil {
+			return err
+		}
+	}
+
+	return sc.Err()
+}
+
+func parseKline(row []byte) (ExchangeState, error) {
+	fields := bytes.Split(row, []byte{','})
+	if len(fields) < klineColumns {
+		return ExchangeState{}, ErrInvalidKline
+	}
+
+	var (
+		e    ExchangeState
+		errs [7]error
+	)
+	e.Unix, errs[0] = utils.ParseUintBytes(fields[klineOpenTime])
+	e.Unix = normalizeUnix(e.Unix)
+	e.Open, errs[1] = utils.ParseDecimalBytes(trimPadding(fields[klineOpen]))
+	e.High, errs[2] = utils.ParseDecimalBytes(trimPadding(fields[klineHigh]))
+	e.Low, errs[3] = utils.ParseDecimalBytes(trimPadding(fields[klineLow]))
+	e.Close, errs[4] = utils.ParseDecimalBytes(trimPadding(fields[klineClose]))
+	e.Volume, errs[5] = utils.ParseDecimalBytes(trimPadding(fields[klineVolume]))
+	e.QuoteVolume, errs[6] = utils.ParseDecimalBytes(trimPadding(fields[klineQuoteVolume]))
+	for _, err := range errs {
+		if err != nil {
+			return e, errors.Wrap(ErrInvalidKline, err.Error())
+		}
+	}
+
+	return e, nil
+}
+
+// archiveParser returns a row parser of the archive by its name like ETHUSDT-aggTrades-2022-01-01.zip
+func archiveParser(file string) func(row []byte) (ExchangeState, error) {
+	name := filepath.Base(file)
+	switch {
+	case strings.Contains(name, "-aggTrades-"):
+		return parseAggTrade
+	case strings.Contains(name, "-trades-"):
+		return parseTrade
+	default:
+		return parseKline
+	}
+}
+
+// archiveSymbol returns a symbol of Binance archive named like ETHUSDT-1m-2022-01-01.zip
+func archiveSymbol(file string) string {
+	name := filepath.Base(file)
+	if i := strings.IndexByte(name, '-'); i > 0 {
+		return strings.ToUpper(name[:i])
+	}
+	return ""
+}
+
+// normalizeUnix converts microsecond timestamps to milliseconds
+func normalizeUnix(ts int64) int64 {
+	if ts >= microsecondsThreshold {
+		return ts / 1000
+	}
+	return ts
+}
+
+// trimPadding removes trailing zeros of the fractional part: 3690.57000000 -> 3690.57, 3688.00000000 -> 3688
+func trimPadding(b []byte) []byte {
+	if bytes.IndexByte(b, '.') == -1 {
+		return b
+	}
+	b = bytes.TrimRight(b, "0")
+	return bytes.TrimSuffix(b, []byte{'.'})
+}
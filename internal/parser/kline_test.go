@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadKlineArchives(t *testing.T) {
+	dir := t.TempDir()
+	writeArchive(t, filepath.Join(dir, "ETHUSDT-1m-2022-01-01.zip"),
+		"1640995200000,3676.22000000,3680.00000000,3676.21000000,3679.98000000,194.01070000,1640995259999,713695.94893700,520,121.65900000,447547.58200000,0\n"+
+			"1640995260000,3679.99000000,3684.57000000,3679.98000000,3684.00000000,182.75380000,1640995319999,672857.35330200,495,89.10470000,328058.55021000,0\n")
+	// monthly archive overlaps the daily one and uses microseconds
+	writeArchive(t, filepath.Join(dir, "ETHUSDT-1m-2022-01.zip"),
+		"open_time,open,high,low,close,volume,close_time,quote_volume,count,taker_buy_volume,taker_buy_quote_volume,ignore\n"+
+			"1640995260000000,3679.99000000,3684.57000000,3679.98000000,3684.00000000,182.75380000,1640995319999999,672857.35330200,495,89.10470000,328058.55021000,0\r\n"+
+			"1640995320000000,3684.01000000,3685.00000000,3683.00000000,3683.50000000,10.00000000,1640995379999999,36835.00000000,12,5.00000000,18417.50000000,0\r\n")
+
+	data, err := collect(func(emit func(e ExchangeState) error) error {
+		return scanDir(dir, "", emit)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 3 {
+		t.Fatalf("loaded %d klines, want 3", len(data))
+	}
+
+	want := []int64{1640995200000, 1640995260000, 1640995320000}
+	for i, e := range data {
+		if e.Unix != want[i] || e.Symbol != "ETHUSDT" {
+			t.Errorf("kline %d = %d %s, want %d ETHUSDT", i, e.Unix, e.Symbol, want[i])
+		}
+	}
+	if data[1].Close.String() != "3684" || data[2].Close.String() != "3683.5" {
+		t.Errorf("close prices = %s %s, want 3684 3683.5", data[1].Close, data[2].Close)
+	}
+}
+
+func writeArchive(t *testing.T, name, content string) {
+	t.Helper()
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(filepath.Base(name[:len(name)-len(".zip")]) + ".csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
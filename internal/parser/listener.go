@@ -2,53 +2,362 @@ package parser
 
 import (
 	"context"
+	"sync"
 	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/internal/book"
 )
 
+// Listener is an independent cursor of the shared read-only store.
+// Playback is changed by control methods and applied by Start between timestamps.
 type Listener struct {
-	states chan ExchangeState
-	data   []ExchangeState
-	delay  time.Duration
+	states  chan ExchangeState
+	store   Store
+	cursor  *Cursor
+	books   map[string]*book.Updates
+	wake    chan struct{}
+	symbols map[string]struct{}
+	// multiSymbol is set when states of several symbols are replayed
+	multiSymbol bool
+	mu          sync.Mutex
+	offset      int
+	end         int64
+	interval    int64
+	delay       time.Duration
+	unix        int64
+	seek        int64
+	seeking     bool
+	paused      bool
+	// step mode sends states of the granted number of timestamps and closes stepDone after them
+	step     bool
+	steps    int
+	stepDone chan struct{}
+	// realtime mode sends states when the wall time reaches their time shifted by shift
+	realtime bool
+	shift    int64
+}
+
+// Playback is a replay state of the listener, Unix is a time of the last sent states
+type Playback struct {
+	Paused   bool
+	Step     bool
+	Realtime bool
+	Unix     int64
+	Delay    time.Duration
 }
 
-func (p *Parser) NewListener() *Listener {
-	return &Listener{
-		states: make(chan ExchangeState),
-		data:   p.data,
-		delay:  p.delay,
+var (
+	ErrInvalidDelay = errors.New("delay must be positive")
+	ErrSeekRange    = errors.New("seek time is out of the dataset")
+	ErrNotStepMode  = errors.New("listener isn't in step mode")
+	ErrInvalidStep  = errors.New("step must be positive")
+	ErrPausedStep   = errors.New("paused listener can't be stepped")
+	ErrRealtime     = errors.New("realtime replay can't be moved in time")
+	ErrRealtimeStep = errors.New("realtime replay can't be stepped")
+)
+
+// NewListener returns a listener replaying the window of the config: symbols from offset to end
+func (p *Parser) NewListener(cfg config.ParserConfig) (*Listener, error) {
+	if cfg.Realtime && cfg.Step {
+		return nil, ErrRealtimeStep
 	}
+
+	l := &Listener{
+		states:   make(chan ExchangeState),
+		store:    p.store,
+		books:    p.books,
+		wake:     make(chan struct{}, 1),
+		offset:   p.store.Search(cfg.Offset),
+		end:      cfg.End,
+		interval: p.interval,
+		delay:    cfg.ListenerDelay,
+		step:     cfg.Step,
+	}
+	// the first timestamp is sent without a step, so the exchange starts with prices
+	if l.step {
+		l.steps = 1
+	}
+	if cfg.Realtime && l.offset < p.store.Len() {
+		l.realtime = true
+		l.shift = realtimeShift(time.Now().UnixMilli(), p.store.Unix(l.offset), l.interval)
+	}
+	l.cursor = p.store.NewCursor(l.offset)
+	l.multiSymbol = len(p.store.Symbols()) > 1
+	if len(cfg.Symbols) > 0 {
+		l.symbols = make(map[string]struct{}, len(cfg.Symbols))
+		for _, symbol := range cfg.Symbols {
+			l.symbols[parseSymbol([]byte(symbol))] = struct{}{}
+		}
+		l.multiSymbol = l.multiSymbol && len(l.symbols) > 1
+	}
+
+	return l, nil
 }
 
 func (l *Listener) Start(ctx context.Context) {
 	defer close(l.states)
+	defer l.cursor.Close()
 
-	idx := 0
-	last := len(l.data) - 1
+	delay := l.Playback().Delay
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
 
-	select {
-	case <-ctx.Done():
-		return
-	case l.states <- l.data[idx]:
-		idx++
-	}
+	for idx := l.offset; ; {
+		var (
+			playback Playback
+			waiting  bool
+		)
+		idx, playback, waiting = l.apply(idx)
+		l.cursor.Move(idx)
+		if playback.Paused || waiting {
+			select {
+			case <-ctx.Done():
+				return
+			case <-l.wake:
+			}
+			continue
+		}
+		if playback.Delay != delay {
+			delay = playback.Delay
+			ticker.Reset(delay)
+		}
+		if idx >= l.store.Len() || (l.end > 0 && l.store.Unix(idx) > l.end) {
+			return
+		}
 
-	ticker := time.NewTicker(l.delay)
-	defer ticker.Stop()
-	for range ticker.C {
-		// check for EOF condition
-		if idx == last {
+		// states of all symbols at the same time are sent on one tick
+		unix, sent := l.store.Unix(idx), false
+		if l.realtime && !l.waitWallTime(ctx, unix) {
 			return
 		}
+		for ; idx < l.store.Len() && l.store.Unix(idx) == unix; idx++ {
+			state := l.store.State(idx)
+			if !l.replays(state.Symbol) {
+				continue
+			}
+			state.Unix += l.shift
+
+			select {
+			case <-ctx.Done():
+				return
+			case l.states <- state:
+				sent = true
+			}
+		}
+		if !sent {
+			continue
+		}
+
+		if l.sent(unix) || l.realtime {
+			continue
+		}
 
 		select {
 		case <-ctx.Done():
 			return
-		case l.states <- l.data[idx]:
-			idx++
+		case <-ticker.C:
+		case <-l.wake:
 		}
 	}
 }
 
+// realtimeShift returns a shift of dataset time to the present.
+// The first kline becomes the last closed one, so the next kline is sent when the current interval ends.
+func realtimeShift(now, first, interval int64) int64 {
+	if interval > 0 {
+		now -= now%interval + interval
+	}
+	return now - first
+}
+
+// waitWallTime waits until states of the time are completed in the wall time: klines are sent after their close
+func (l *Listener) waitWallTime(ctx context.Context, unix int64) bool {
+	timer := time.NewTimer(time.Until(time.UnixMilli(unix + l.shift + l.interval)))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// replays reports whether states of the symbol are replayed, states without symbol are used for any symbol
+func (l *Listener) replays(symbol string) bool {
+	if l.symbols == nil || symbol == "" {
+		return true
+	}
+	_, ok := l.symbols[symbol]
+	return ok
+}
+
+// apply moves the cursor to the requested time and returns the playback.
+// It reports whether the listener waits for a step.
+func (l *Listener) apply(idx int) (int, Playback, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.seeking {
+		idx = l.store.Search(l.seek)
+		l.seeking = false
+	}
+
+	return idx, l.playback(), l.step && l.steps == 0
+}
+
+// sent keeps the time of sent states and counts the step, it reports whether the listener is in step mode
+func (l *Listener) sent(unix int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.unix = unix + l.shift
+	if !l.step {
+		return false
+	}
+
+	l.steps--
+	if l.steps == 0 && l.stepDone != nil {
+		close(l.stepDone)
+		l.stepDone = nil
+	}
+	return true
+}
+
+// Step grants sending states of n more timestamps in step mode.
+// The returned channel is closed after states of the last granted timestamp are received.
+// Paused listener sends nothing, so it can't be stepped until Resume.
+func (l *Listener) Step(n int) (<-chan struct{}, error) {
+	if !l.step {
+		return nil, ErrNotStepMode
+	}
+	if n <= 0 {
+		return nil, ErrInvalidStep
+	}
+
+	l.mu.Lock()
+	if l.paused {
+		l.mu.Unlock()
+		return nil, ErrPausedStep
+	}
+	l.steps += n
+	if l.stepDone == nil {
+		l.stepDone = make(chan struct{})
+	}
+	done := l.stepDone
+	l.mu.Unlock()
+
+	l.notify()
+
+	return done, nil
+}
+
+// Pause stops sending states until Resume. Realtime replay catches up with the wall time after Resume.
+func (l *Listener) Pause() Playback {
+	return l.control(func() {
+		l.paused = true
+	})
+}
+
+func (l *Listener) Resume() Playback {
+	return l.control(func() {
+		l.paused = false
+	})
+}
+
+// SeekTime moves the replay to the first states not before the time. States skipped forward aren't matched.
+func (l *Listener) SeekTime(unix int64) (Playback, error) {
+	if l.realtime {
+		return l.Playback(), ErrRealtime
+	}
+	if l.store.Len() == 0 || unix > l.store.Unix(l.store.Len()-1) || (l.end > 0 && unix > l.end) {
+		return l.Playback(), errors.Wrapf(ErrSeekRange, "%d", unix)
+	}
+
+	return l.control(func() {
+		l.seek, l.seeking = unix, true
+		l.unix = unix
+	}), nil
+}
+
+// SetDelay changes a delay between timestamps of the replay
+func (l *Listener) SetDelay(delay time.Duration) (Playback, error) {
+	if l.realtime {
+		return l.Playback(), ErrRealtime
+	}
+	if delay <= 0 {
+		return l.Playback(), ErrInvalidDelay
+	}
+
+	return l.control(func() {
+		l.delay = delay
+	}), nil
+}
+
+func (l *Listener) Playback() Playback {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.playback()
+}
+
+func (l *Listener) playback() Playback {
+	return Playback{
+		Paused:   l.paused,
+		Step:     l.step,
+		Realtime: l.realtime,
+		Unix:     l.unix,
+		Delay:    l.delay,
+	}
+}
+
+// control changes the playback and wakes up Start to apply it
+func (l *Listener) control(change func()) Playback {
+	l.mu.Lock()
+	change()
+	playback := l.playback()
+	l.mu.Unlock()
+
+	l.notify()
+
+	return playback
+}
+
+func (l *Listener) notify() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Books returns order book updates per symbol
+func (l *Listener) Books() map[string]*book.Updates {
+	return l.books
+}
+
+// Shift returns a shift of sent states from the dataset time, it's zero unless the replay is realtime
+func (l *Listener) Shift() int64 {
+	return l.shift
+}
+
+// Realtime reports whether states are sent at the wall time
+func (l *Listener) Realtime() bool {
+	return l.realtime
+}
+
+// MultiSymbol reports whether states of several symbols are replayed
+func (l *Listener) MultiSymbol() bool {
+	return l.multiSymbol
+}
+
+// Interval returns the base interval of klines, zero for trades
+func (l *Listener) Interval() int64 {
+	return l.interval
+}
+
 func (l *Listener) ExchangeStates() <-chan ExchangeState {
 	return l.states
 }
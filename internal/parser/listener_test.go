@@ -0,0 +1,216 @@
+package parser
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+type sliceStore []ExchangeState
+
+func (s sliceStore) Len() int                  { return len(s) }
+func (s sliceStore) Unix(i int) int64          { return s[i].Unix }
+func (s sliceStore) State(i int) ExchangeState { return s[i] }
+func (s sliceStore) NewCursor(int) *Cursor     { return nil }
+func (s sliceStore) Symbols() []string         { return nil }
+func (s sliceStore) Search(unix int64) int {
+	return sort.Search(len(s), func(i int) bool { return s[i].Unix >= unix })
+}
+
+func TestListenerPlayback(t *testing.T) {
+	var store sliceStore
+	for i := int64(0); i < 100; i++ {
+		store = append(store, ExchangeState{Unix: i * 60000})
+	}
+	l := &Listener{
+		states: make(chan ExchangeState),
+		store:  store,
+		wake:   make(chan struct{}, 1),
+		delay:  time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go l.Start(ctx)
+
+	if state := <-l.states; state.Unix != 0 {
+		t.Fatalf("first state = %d", state.Unix)
+	}
+
+	l.Pause()
+	// a state may be already sent before the pause is applied
+	select {
+	case <-l.states:
+	case <-time.After(10 * time.Millisecond):
+	}
+	select {
+	case state := <-l.states:
+		t.Fatalf("state %d is sent while paused", state.Unix)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if _, err := l.SeekTime(store[99].Unix + 1); !errors.Is(err, ErrSeekRange) {
+		t.Fatalf("SeekTime() error = %v, want %v", err, ErrSeekRange)
+	}
+	if _, err := l.SetDelay(0); !errors.Is(err, ErrInvalidDelay) {
+		t.Fatalf("SetDelay() error = %v, want %v", err, ErrInvalidDelay)
+	}
+
+	if _, err := l.SeekTime(50*60000 - 1); err != nil {
+		t.Fatal(err)
+	}
+	playback, err := l.SetDelay(2 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !playback.Paused || playback.Delay != 2*time.Millisecond {
+		t.Errorf("playback = %+v", playback)
+	}
+
+	l.Resume()
+	if state := <-l.states; state.Unix != 50*60000 {
+		t.Fatalf("state after seek = %d", state.Unix)
+	}
+}
+
+func TestListenerWindow(t *testing.T) {
+	var store sliceStore
+	for i := int64(0); i < 10; i++ {
+		store = append(store, ExchangeState{Symbol: "ETHUSDT", Unix: i}, ExchangeState{Symbol: "BTCUSDT", Unix: i})
+	}
+	l := &Listener{
+		states:  make(chan ExchangeState),
+		store:   store,
+		wake:    make(chan struct{}, 1),
+		symbols: map[string]struct{}{"BTCUSDT": {}},
+		offset:  store.Search(3),
+		end:     5,
+		delay:   time.Microsecond,
+	}
+	go l.Start(context.Background())
+
+	var got []int64
+	for state := range l.states {
+		if state.Symbol != "BTCUSDT" {
+			t.Fatalf("state of %s is replayed", state.Symbol)
+		}
+		got = append(got, state.Unix)
+	}
+	if len(got) != 3 || got[0] != 3 || got[2] != 5 {
+		t.Errorf("replayed states = %v", got)
+	}
+}
+
+func TestListenerStep(t *testing.T) {
+	var store sliceStore
+	for i := int64(0); i < 10; i++ {
+		store = append(store, ExchangeState{Symbol: "ETHUSDT", Unix: i}, ExchangeState{Symbol: "BTCUSDT", Unix: i})
+	}
+	l := &Listener{
+		states: make(chan ExchangeState),
+		store:  store,
+		wake:   make(chan struct{}, 1),
+		delay:  time.Hour,
+		step:   true,
+		steps:  1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go l.Start(ctx)
+
+	// the first timestamp is sent without a step
+	for i := 0; i < 2; i++ {
+		if state := <-l.states; state.Unix != 0 {
+			t.Fatalf("first state = %d", state.Unix)
+		}
+	}
+	select {
+	case state := <-l.states:
+		t.Fatalf("state %d is sent without a step", state.Unix)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	done, err := l.Step(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int64
+	for {
+		select {
+		case state := <-l.states:
+			got = append(got, state.Unix)
+			continue
+		case <-done:
+		}
+		break
+	}
+	if len(got) != 4 || got[0] != 1 || got[3] != 2 {
+		t.Errorf("step states = %v", got)
+	}
+	if playback := l.Playback(); !playback.Step || playback.Unix != 2 {
+		t.Errorf("playback = %+v", playback)
+	}
+
+	if _, err = l.Step(0); !errors.Is(err, ErrInvalidStep) {
+		t.Fatalf("Step() error = %v, want %v", err, ErrInvalidStep)
+	}
+
+	l.Pause()
+	if _, err = l.Step(1); !errors.Is(err, ErrPausedStep) {
+		t.Fatalf("Step() of paused listener error = %v, want %v", err, ErrPausedStep)
+	}
+	l.Resume()
+	if done, err = l.Step(1); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if state := <-l.states; state.Unix != 3 {
+			t.Fatalf("state after resume = %d, want 3", state.Unix)
+		}
+	}
+	<-done
+}
+
+func TestListenerRealtime(t *testing.T) {
+	const interval = 50
+	var store sliceStore
+	for i := int64(0); i < 3; i++ {
+		store = append(store, ExchangeState{Unix: 1000 + i*interval})
+	}
+	now := time.Now().UnixMilli()
+	l := &Listener{
+		states:   make(chan ExchangeState),
+		store:    store,
+		wake:     make(chan struct{}, 1),
+		interval: interval,
+		delay:    time.Hour,
+		realtime: true,
+		shift:    realtimeShift(now, store[0].Unix, interval),
+	}
+	if _, err := l.SeekTime(store[1].Unix); !errors.Is(err, ErrRealtime) {
+		t.Fatalf("SeekTime() error = %v, want %v", err, ErrRealtime)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go l.Start(ctx)
+
+	// the first kline is the last closed one, the next ones are sent after their close
+	first := <-l.states
+	if first.Unix%interval != 0 || first.Unix > now-interval || first.Unix <= now-2*interval {
+		t.Fatalf("first state = %d, now = %d", first.Unix, now)
+	}
+	for i := 1; i < len(store); i++ {
+		state := <-l.states
+		if state.Unix != first.Unix+int64(i)*interval {
+			t.Fatalf("state %d = %d", i, state.Unix)
+		}
+		if closed := state.Unix + interval; time.Now().UnixMilli() < closed {
+			t.Fatalf("state %d is sent before its close", state.Unix)
+		}
+	}
+}
@@ -1,26 +1,333 @@
 package parser
 
 import (
-	"time"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/phuslu/log"
 
 	"github.com/xenking/exchange-emulator/config"
+	"github.com/xenking/exchange-emulator/internal/book"
 	"github.com/xenking/exchange-emulator/pkg/fastcsv"
 )
 
+// Store is a read-only timeline of states ordered by time shared by listeners.
+// Listeners keep their positions in cursors, so the store may release states behind them.
+type Store interface {
+	Len() int
+	Unix(i int) int64
+	Search(unix int64) int
+	State(i int) ExchangeState
+	Symbols() []string
+	NewCursor(pos int) *Cursor
+}
+
 type Parser struct {
-	data  []ExchangeState
-	delay time.Duration
+	store    Store
+	books    map[string]*book.Updates
+	interval int64
 }
 
+// New opens the binary cache of configured datasets. The cache is built on first load
+// and rebuilt when dataset files are modified.
 func New(cfg config.ParserConfig) (*Parser, error) {
 	p := &Parser{
-		delay: cfg.ListenerDelay,
+		books: make(map[string]*book.Updates, len(cfg.Books)),
+	}
+
+	for _, dataset := range cfg.Books {
+		updates, err := book.Load(dataset.File, parseSymbol([]byte(dataset.Symbol)))
+		if err != nil {
+			return p, err
+		}
+		p.books[updates.Symbol] = updates
+	}
+
+	datasets := parserDatasets(cfg)
+	modTime, err := datasetsModTime(datasets)
+	if err != nil {
+		return p, err
+	}
+
+	path := cachePath(cfg.CacheDir, datasets, cfg.Validation)
+	cache, err := OpenCache(path)
+	if err == nil && cache.ModTime() != modTime {
+		_ = cache.Close()
+		err = ErrInvalidCache
+	}
+	if err != nil {
+		if _, _, err = BuildCache(cfg); err != nil {
+			return p, err
+		}
+		if cache, err = OpenCache(path); err != nil {
+			return p, err
+		}
 	}
-	err := p.start(cfg.File, cfg.Offset)
-	return p, err
+
+	p.store = cache
+	p.interval = baseInterval(p.store)
+
+	return p, nil
 }
 
-func (p *Parser) start(file string, offset int64) error {
+// BuildCache streams configured datasets through validation into temporary spill files of the cache directory
+// and merges them into the binary cache, so datasets aren't held in memory.
+// It returns the cache file and a number of cached states.
+func BuildCache(cfg config.ParserConfig) (string, int, error) {
+	if err := CheckPolicies(cfg.Validation); err != nil {
+		return "", 0, err
+	}
+
+	datasets := parserDatasets(cfg)
+	modTime, err := datasetsModTime(datasets)
+	if err != nil {
+		return "", 0, err
+	}
+
+	symbols := newSymbolTable()
+	spills := make([]*spill, 0, len(datasets))
+	defer func() {
+		for _, s := range spills {
+			_ = s.Close()
+		}
+	}()
+	for _, dataset := range datasets {
+		s, err := newSpill(cfg.CacheDir, symbols)
+		if err != nil {
+			return "", 0, err
+		}
+		spills = append(spills, s)
+
+		if _, err = validateDataset(dataset, cfg.Validation, s.add); err != nil {
+			return "", 0, err
+		}
+		if err = s.open(); err != nil {
+			return "", 0, err
+		}
+	}
+
+	path := cachePath(cfg.CacheDir, datasets, cfg.Validation)
+	count, err := writeMerged(path, spills, modTime, symbols)
+	return path, count, err
+}
+
+// Validate streams configured datasets through validation and reports their problems without building the cache
+func Validate(cfg config.ParserConfig) ([]Report, error) {
+	if err := CheckPolicies(cfg.Validation); err != nil {
+		return nil, err
+	}
+
+	datasets := parserDatasets(cfg)
+	reports := make([]Report, 0, len(datasets))
+	for _, dataset := range datasets {
+		report, err := validateDataset(dataset, cfg.Validation, func(ExchangeState) error { return nil })
+		if report != nil {
+			reports = append(reports, *report)
+		}
+		if err != nil {
+			return reports, err
+		}
+	}
+
+	return reports, nil
+}
+
+// validateDataset sends valid states of the dataset to emit, the report is logged.
+// The dataset is read twice: the first pass finds the kline interval gaps are checked with.
+// The report is nil if the dataset can't be read.
+func validateDataset(dataset config.DatasetConfig, validation config.ValidationConfig,
+	emit func(e ExchangeState) error,
+) (*Report, error) {
+	symbol := parseSymbol([]byte(dataset.Symbol))
+	report := &Report{File: dataset.File}
+
+	var (
+		last    int64
+		started bool
+		tick    bool
+	)
+	err := scan(dataset.File, symbol, func(e ExchangeState) error {
+		if !started {
+			report.Symbol, tick = e.Symbol, e.Tick
+		} else if step := e.Unix - last; step > 0 && (report.Interval == 0 || step < report.Interval) {
+			report.Interval = step
+		}
+		last, started = e.Unix, true
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, dataset.File)
+	}
+	if tick {
+		report.Interval = 0
+	}
+
+	err = scan(dataset.File, symbol, newValidator(validation, report, emit).add)
+	logReport(report)
+	if err != nil {
+		return report, errors.Wrap(err, dataset.File)
+	}
+
+	return report, nil
+}
+
+// mergeSource is a spilled dataset read in time order
+type mergeSource struct {
+	spill *spill
+	order []int32
+	head  int
+}
+
+func (s *mergeSource) done() bool {
+	return s.head == s.spill.count
+}
+
+func (s *mergeSource) index() int {
+	if s.order != nil {
+		return int(s.order[s.head])
+	}
+	return s.head
+}
+
+func (s *mergeSource) unix() int64 {
+	return s.spill.unix(s.index())
+}
+
+func (s *mergeSource) next() ExchangeState {
+	e := s.spill.state(s.index())
+	s.head++
+	return e
+}
+
+// writeMerged combines spilled datasets into one timeline ordered by timestamp and writes it to the cache file.
+// States with equal timestamps keep the order of datasets. It returns a number of written states.
+func writeMerged(filename string, spills []*spill, modTime int64, symbols *symbolTable) (int, error) {
+	header := cacheHeader{modTime: modTime}
+	sources := make([]mergeSource, len(spills))
+	for i, s := range spills {
+		header.count += s.count
+		if s.priceExp < header.priceExp {
+			header.priceExp = s.priceExp
+		}
+		if s.volumeExp < header.volumeExp {
+			header.volumeExp = s.volumeExp
+		}
+		if s.quoteExp < header.quoteExp {
+			header.quoteExp = s.quoteExp
+		}
+		sources[i] = mergeSource{spill: s, order: s.order()}
+	}
+
+	w, err := newCacheWriter(filename, header, symbols)
+	if err != nil {
+		return 0, err
+	}
+	defer w.abort()
+
+	for {
+		next := -1
+		for i := range sources {
+			if sources[i].done() {
+				continue
+			}
+			if next == -1 || sources[i].unix() < sources[next].unix() {
+				next = i
+			}
+		}
+		if next == -1 {
+			break
+		}
+		if err = w.add(sources[next].next()); err != nil {
+			return 0, err
+		}
+	}
+
+	return header.count, w.commit()
+}
+
+func logReport(r *Report) {
+	for _, issue := range r.Issues {
+		log.Warn().Str("file", r.File).Str("problem", string(issue.Problem)).Int64("unix", issue.Unix).
+			Int64("missing", issue.Missing).Msg("dataset problem")
+	}
+
+	entry := log.Info()
+	if !r.Valid() {
+		entry = log.Warn()
+	}
+	entry.Str("file", r.File).Str("symbol", r.Symbol).Int("states", r.States).Int64("interval", r.Interval).
+		Int("gaps", r.Gaps).Int64("missing", r.Missing).Int("duplicates", r.Duplicates).
+		Int("out_of_order", r.OutOfOrder).Int("ohlc", r.OHLC).Int("zero_volume", r.ZeroVolume).
+		Int("filled", r.Filled).Int("skipped", r.Skipped).Msg("dataset validated")
+}
+
+// baseIntervalStates is a number of states the base interval is detected on
+const baseIntervalStates = 10000
+
+// baseInterval returns the smallest step between timestamps of klines, zero for trades
+func baseInterval(store Store) int64 {
+	var interval int64
+	for i := 1; i < store.Len() && i < baseIntervalStates; i++ {
+		if store.State(i).Tick {
+			return 0
+		}
+		if step := store.Unix(i) - store.Unix(i-1); step > 0 && (interval == 0 || step < interval) {
+			interval = step
+		}
+	}
+	return interval
+}
+
+func parserDatasets(cfg config.ParserConfig) []config.DatasetConfig {
+	if len(cfg.Datasets) == 0 {
+		return []config.DatasetConfig{{File: cfg.File}}
+	}
+	return cfg.Datasets
+}
+
+// scan sends states of the dataset file: a csv with header, a Binance kline archive, a directory of archives
+// or a generator spec of synthetic klines
+func scan(file, symbol string, emit func(e ExchangeState) error) error {
+	if isGenerator(file) {
+		return scanGenerator(file, symbol, emit)
+	}
+
+	fi, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case fi.IsDir():
+		return scanDir(file, symbol, emit)
+	case isArchive(file):
+		return scanFile(file, symbol, emit)
+	default:
+		return scanCSV(file, symbol, emit)
+	}
+}
+
+// isArchive reports whether the file is a Binance archive: zip, trades or csv without header
+func isArchive(file string) bool {
+	name := filepath.Base(file)
+	if filepath.Ext(name) == ".zip" || strings.Contains(name, "-aggTrades-") || strings.Contains(name, "-trades-") {
+		return true
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	var b [1]byte
+	_, err = f.Read(b[:])
+	return err == nil && b[0] >= '0' && b[0] <= '9'
+}
+
+func scanCSV(file, symbol string, emit func(e ExchangeState) error) error {
 	row := &exchangeState{}
 	reader, err := fastcsv.NewFileReader(file, ',', row)
 	if err != nil {
@@ -29,16 +336,17 @@ func (p *Parser) start(file string, offset int64) error {
 
 	defer reader.Close()
 
-	var line ExchangeState
-	for reader.Scan() {
-		line = row.Parse()
-		if line.Unix < offset {
-			continue
+	for n := 2; reader.Scan(); n++ {
+		line, err := row.Parse()
+		if err != nil {
+			return errors.Wrapf(err, "line %d", n)
+		}
+		if symbol != "" {
+			line.Symbol = symbol
+		}
+		if err = emit(line); err != nil {
+			return err
 		}
-
-		line.Raw = make([]byte, 0, 15)
-		line.Raw = line.AppendEncoded(line.Raw)
-		p.data = append(p.data, line)
 	}
 
 	return nil
@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xenking/exchange-emulator/config"
+)
+
+func TestLoadCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		row    string
+		volume string
+		quote  string
+	}{
+		{
+			name:   "base asset volume",
+			header: "unix,date,symbol,open,high,low,close,Volume BTC,Volume USDT,tradecount",
+			row:    "1640995200000,2022-01-01 00:00:00,BTC/USDT,46216.93,46271.08,46208.37,46250.00,40.57574,1876626.53,1234",
+			volume: "40.57574", quote: "1876626.53",
+		},
+		{
+			name:   "without volume",
+			header: "unix,date,symbol,open,high,low,close",
+			row:    "1640995200000,2022-01-01 00:00:00,BTC/USDT,46216.93,46271.08,46208.37,46250.00",
+			volume: "0", quote: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "klines.csv")
+			if err := os.WriteFile(file, []byte(tt.header+"\n"+tt.row+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := collect(func(emit func(e ExchangeState) error) error {
+				return scanCSV(file, "", emit)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data) != 1 || data[0].Symbol != "BTCUSDT" || data[0].Close.String() != "46250" {
+				t.Fatalf("scanCSV() = %+v", data)
+			}
+			if data[0].Volume.String() != tt.volume || data[0].QuoteVolume.String() != tt.quote {
+				t.Errorf("volume = %s, quote volume = %s, want %s and %s", data[0].Volume, data[0].QuoteVolume, tt.volume, tt.quote)
+			}
+		})
+	}
+}
+
+// collect returns states sent by the scan
+func collect(scan func(emit func(e ExchangeState) error) error) ([]ExchangeState, error) {
+	var data []ExchangeState
+	err := scan(func(e ExchangeState) error {
+		data = append(data, e)
+		return nil
+	})
+	return data, err
+}
+
+func TestBuildCache(t *testing.T) {
+	dir := t.TempDir()
+	eth := filepath.Join(dir, "eth.csv")
+	// the last kline is out of order and sorted by the cache
+	if err := os.WriteFile(eth, []byte("unix,date,symbol,open,high,low,close,Volume ETH,Volume USDT\n"+
+		"1640995200000,,ETH/USDT,100,101,99,100.5,10,1000\n"+
+		"1640995320000,,ETH/USDT,101,102,100,101.5,10,1000\n"+
+		"1640995260000,,ETH/USDT,100.5,101,100,101,10,1000\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	btc := filepath.Join(dir, "btc.csv")
+	if err := os.WriteFile(btc, []byte("unix,date,symbol,open,high,low,close,Volume BTC,Volume USDT\n"+
+		"1640995200000,,BTC/USDT,46216.93,46271.08,46208.37,46250.001,0.5,23125\n"+
+		"1640995260000,,BTC/USDT,46250,46260,46240,46255,0.25,11563.75\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.ParserConfig{
+		Datasets:   []config.DatasetConfig{{File: eth}, {File: btc}},
+		CacheDir:   dir,
+		Validation: config.ValidationConfig{Gaps: "ignore", Duplicates: "ignore", OHLC: "ignore", ZeroVolume: "ignore"},
+	}
+	file, count, err := BuildCache(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 5 {
+		t.Fatalf("cached %d states, want 5", count)
+	}
+
+	c, err := OpenCache(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	want := []string{
+		"1640995200000 ETHUSDT 100.5", "1640995200000 BTCUSDT 46250.001", "1640995260000 ETHUSDT 101",
+		"1640995260000 BTCUSDT 46255", "1640995320000 ETHUSDT 101.5",
+	}
+	for i := range want {
+		e := c.State(i)
+		if got := fmt.Sprintf("%d %s %s", e.Unix, e.Symbol, e.Close); got != want[i] {
+			t.Errorf("state %d = %s, want %s", i, got, want[i])
+		}
+	}
+
+	spills, err := filepath.Glob(filepath.Join(dir, "spill-*"))
+	if err != nil || len(spills) != 0 {
+		t.Errorf("spill files %v are left", spills)
+	}
+}
@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/binary"
+	"os"
+	"sort"
+	"syscall"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+)
+
+// spillRecordSize is a size of the spilled state:
+// unix i64 | open, high, low, close, volume, quoteVolume mantissas i64 | their exponents i32 | symbol u16 | tick u8
+const spillRecordSize = 8 + 6*8 + 6*4 + 2 + 1
+
+// symbolTable assigns cache ids to symbols in the order they are met
+type symbolTable struct {
+	ids   map[string]uint16
+	names []string
+	ticks []bool
+}
+
+func newSymbolTable() *symbolTable {
+	return &symbolTable{ids: make(map[string]uint16)}
+}
+
+func (s *symbolTable) id(symbol string, tick bool) (uint16, error) {
+	if id, ok := s.ids[symbol]; ok {
+		return id, nil
+	}
+	if len(s.names) == maxCacheSymbols || len(symbol) > 255 {
+		return 0, errors.Wrap(ErrInvalidCache, "too many or too long symbols")
+	}
+	id := uint16(len(s.names))
+	s.ids[symbol] = id
+	s.names = append(s.names, symbol)
+	s.ticks = append(s.ticks, tick)
+	return id, nil
+}
+
+// spill is a temporary file of validated states of one dataset, so datasets aren't kept in memory
+// while the cache is built. It's written sequentially and mapped for reading once it's complete.
+type spill struct {
+	f       *os.File
+	w       *bufio.Writer
+	data    []byte
+	symbols *symbolTable
+	count   int
+	// sorted is false when states are added out of order
+	sorted    bool
+	last      int64
+	priceExp  int32
+	volumeExp int32
+	quoteExp  int32
+	record    [spillRecordSize]byte
+}
+
+func newSpill(dir string, symbols *symbolTable) (*spill, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(dir, "spill-*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &spill{f: f, w: bufio.NewWriter(f), symbols: symbols, sorted: true}, nil
+}
+
+// add appends the state to the spill file
+func (s *spill) add(e ExchangeState) error {
+	id, err := s.symbols.id(e.Symbol, e.Tick)
+	if err != nil {
+		return err
+	}
+
+	le := binary.LittleEndian
+	b := s.record[:]
+	le.PutUint64(b, uint64(e.Unix))
+	for i, d := range [...]decimal.Decimal{e.Open, e.High, e.Low, e.Close, e.Volume, e.QuoteVolume} {
+		m, err := fixedMantissa(d, d.Exponent())
+		if err != nil {
+			return errors.Wrapf(err, "state %d", e.Unix)
+		}
+		le.PutUint64(b[8+i*8:], uint64(m))
+		le.PutUint32(b[56+i*4:], uint32(d.Exponent()))
+	}
+	le.PutUint16(b[80:], id)
+	b[82] = 0
+	if e.Tick {
+		b[82] = 1
+	}
+	if _, err = s.w.Write(b); err != nil {
+		return err
+	}
+
+	s.priceExp = minExponent(s.priceExp, e.Open, e.High, e.Low, e.Close)
+	s.volumeExp = minExponent(s.volumeExp, e.Volume)
+	s.quoteExp = minExponent(s.quoteExp, e.QuoteVolume)
+	if s.count > 0 && e.Unix < s.last {
+		s.sorted = false
+	}
+	s.last = e.Unix
+	s.count++
+
+	return nil
+}
+
+// open completes the spill file and maps it for reading
+func (s *spill) open() error {
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	if s.count == 0 {
+		return nil
+	}
+
+	data, err := syscall.Mmap(int(s.f.Fd()), 0, s.count*spillRecordSize, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+	_ = syscall.Madvise(data, syscall.MADV_SEQUENTIAL)
+	s.data = data
+
+	return nil
+}
+
+// unix returns a timestamp of the i-th state
+func (s *spill) unix(i int) int64 {
+	return int64(binary.LittleEndian.Uint64(s.data[i*spillRecordSize:]))
+}
+
+// state decodes the i-th state
+func (s *spill) state(i int) ExchangeState {
+	le := binary.LittleEndian
+	b := s.data[i*spillRecordSize : (i+1)*spillRecordSize]
+	var values [6]decimal.Decimal
+	for j := range values {
+		values[j] = decimal.New(int64(le.Uint64(b[8+j*8:])), int32(le.Uint32(b[56+j*4:])))
+	}
+	return ExchangeState{
+		Open:        values[0],
+		High:        values[1],
+		Low:         values[2],
+		Close:       values[3],
+		Volume:      values[4],
+		QuoteVolume: values[5],
+		Symbol:      s.symbols.names[le.Uint16(b[80:])],
+		Tick:        b[82] == 1,
+		Unix:        int64(le.Uint64(b)),
+	}
+}
+
+// order returns indexes of states ordered by time, nil if states are added in order.
+// Equal timestamps keep the order of states.
+func (s *spill) order() []int32 {
+	if s.sorted {
+		return nil
+	}
+	idx := make([]int32, s.count)
+	for i := range idx {
+		idx[i] = int32(i)
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return s.unix(int(idx[i])) < s.unix(int(idx[j]))
+	})
+	return idx
+}
+
+// Close unmaps and removes the spill file
+func (s *spill) Close() error {
+	if s.data != nil {
+		_ = syscall.Munmap(s.data)
+		s.data = nil
+	}
+	_ = s.f.Close()
+	return os.Remove(s.f.Name())
+}
@@ -2,6 +2,9 @@ package parser
 
 import (
 	"encoding/binary"
+	"strconv"
+
+	"github.com/go-faster/errors"
 
 	"github.com/xenking/decimal"
 
@@ -10,14 +13,21 @@ import (
 
 // header
 // unix,date,symbol,open,high,low,close,Volume ETH,Volume USDT,tradecount
+// Volume columns are named by assets: the first one is base volume, the second is quote volume
 
 type ExchangeState struct {
-	Open  decimal.Decimal `json:"open"`
-	High  decimal.Decimal `json:"high"`
-	Low   decimal.Decimal `json:"low"`
-	Close decimal.Decimal `json:"close"`
-	Raw   []byte          `json:"-"`
-	Unix  int64           `json:"unix"`
+	Open        decimal.Decimal `json:"open"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Close       decimal.Decimal `json:"close"`
+	Volume      decimal.Decimal `json:"volume"`
+	QuoteVolume decimal.Decimal `json:"-"`
+	Symbol      string          `json:"symbol"`
+	// Interval is set for resampled klines
+	Interval string `json:"interval,omitempty"`
+	// Tick is set for states of a single trade
+	Tick bool  `json:"-"`
+	Unix int64 `json:"unix"`
 }
 
 func (e ExchangeState) MarshalJSON() ([]byte, error) {
@@ -27,8 +37,15 @@ func (e ExchangeState) MarshalJSON() ([]byte, error) {
 }
 
 func (e ExchangeState) AppendMarshalJSON(b []byte) []byte {
-	// {"open":"3690.57","high":"3691.03","low":"3688.00","close":"3690.09","unix":1640995440000}
-	b = append(b, `{"open":"`...)
+	// {"symbol":"ETHUSDT","interval":"5m","open":"3690.57","high":"3691.03","low":"3688.00","close":"3690.09",
+	// "volume":"120.5","unix":1640995440000}
+	b = append(b, `{"symbol":"`...)
+	b = append(b, e.Symbol...)
+	if e.Interval != "" {
+		b = append(b, `","interval":"`...)
+		b = append(b, e.Interval...)
+	}
+	b = append(b, `","open":"`...)
 	b = utils.AppendDecimal(b, e.Open)
 	b = appendZeroExponent(b, e.Open.Exponent())
 	b = append(b, `","high":"`...)
@@ -40,20 +57,76 @@ func (e ExchangeState) AppendMarshalJSON(b []byte) []byte {
 	b = append(b, `","close":"`...)
 	b = utils.AppendDecimal(b, e.Close)
 	b = appendZeroExponent(b, e.Close.Exponent())
+	b = append(b, `","volume":"`...)
+	b = append(b, e.Volume.String()...)
 	b = append(b, `","unix":`...)
-	b = binary.BigEndian.AppendUint64(b, uint64(e.Unix))
+	b = utils.AppendUint(b, e.Unix)
 	b = append(b, '}')
 	return b
 }
 
 func (e ExchangeState) AppendEncoded(b []byte) []byte {
-	// 3690.09|1640995440000 == 15 raw bytes
+	// 1640995440000|3690.09 == 15 raw bytes
 	b = binary.BigEndian.AppendUint64(b, uint64(e.Unix))
 	b = utils.AppendDecimal(b, e.Close)
 	b = appendZeroExponent(b, e.Close.Exponent())
 	return b
 }
 
+// KlineFrame is the first byte of resampled kline frames on the prices WS.
+// Price frames start with a big endian unix time, its first byte is zero.
+const KlineFrame = 'K'
+
+// AppendEncodedKline appends the resampled kline frame
+func (e ExchangeState) AppendEncodedKline(b []byte) []byte {
+	// K|1640995440000|5m|3690.57|3691.03|3688|3690.09|120.5 == 9 raw bytes + interval, prices and volume
+	b = append(b, KlineFrame)
+	b = binary.BigEndian.AppendUint64(b, uint64(e.Unix))
+	b = append(b, e.Interval...)
+	for _, d := range [...]decimal.Decimal{e.Open, e.High, e.Low, e.Close, e.Volume} {
+		b = append(b, '|')
+		b = appendDecimal(b, d)
+	}
+	return b
+}
+
+// appendDecimal appends the decimal in plain notation without allocation
+func appendDecimal(b []byte, d decimal.Decimal) []byte {
+	coef, exp := d.CoefficientInt64(), int(d.Exponent())
+	if coef < 0 {
+		b = append(b, '-')
+		coef = -coef
+	}
+
+	var buf [24]byte
+	digits := strconv.AppendInt(buf[:0], coef, 10)
+	if exp >= 0 {
+		b = append(b, digits...)
+		for ; exp > 0 && coef != 0; exp-- {
+			b = append(b, '0')
+		}
+		return b
+	}
+
+	point := len(digits) + exp
+	if point <= 0 {
+		b = append(b, '0', '.')
+		for ; point < 0; point++ {
+			b = append(b, '0')
+		}
+		return append(b, digits...)
+	}
+	b = append(b, digits[:point]...)
+	b = append(b, '.')
+	return append(b, digits[point:]...)
+}
+
+// appendSymbol appends the symbol suffix of price frames of multi-symbol replays
+func appendSymbol(b []byte, symbol string) []byte {
+	b = append(b, '|')
+	return append(b, symbol...)
+}
+
 func appendZeroExponent(dst []byte, exp int32) []byte {
 	if exp != 0 {
 		return dst
@@ -69,17 +142,49 @@ type exchangeState struct {
 	High        []byte `csv:"high"`
 	Low         []byte `csv:"low"`
 	Close       []byte `csv:"close"`
-	BaseVolume  []byte `csv:"Volume ETH"`
-	AssetVolume []byte `csv:"Volume USDT"`
+	BaseVolume  []byte `csv:"Volume *"`
+	AssetVolume []byte `csv:"Volume *"`
 	Trades      []byte `csv:"trades"`
 }
 
-func (s *exchangeState) Parse() ExchangeState {
-	e := ExchangeState{}
-	e.Unix, _ = utils.ParseUintBytes(s.Unix)
-	e.Open, _ = utils.ParseDecimalBytes(s.Open[:len(s.Open)-6])
-	e.High, _ = utils.ParseDecimalBytes(s.High[:len(s.High)-6])
-	e.Low, _ = utils.ParseDecimalBytes(s.Low[:len(s.Low)-6])
-	e.Close, _ = utils.ParseDecimalBytes(s.Close[:len(s.Close)-6])
-	return e
+func (s *exchangeState) Parse() (ExchangeState, error) {
+	var (
+		e    ExchangeState
+		errs [7]error
+	)
+	e.Unix, errs[0] = utils.ParseUintBytes(s.Unix)
+	e.Unix = normalizeUnix(e.Unix)
+	e.Symbol = parseSymbol(s.Symbol)
+	e.Open, errs[1] = utils.ParseDecimalBytes(trimPadding(s.Open))
+	e.High, errs[2] = utils.ParseDecimalBytes(trimPadding(s.High))
+	e.Low, errs[3] = utils.ParseDecimalBytes(trimPadding(s.Low))
+	e.Close, errs[4] = utils.ParseDecimalBytes(trimPadding(s.Close))
+	// files without volume columns have zero volume
+	if s.BaseVolume != nil {
+		e.Volume, errs[5] = utils.ParseDecimalBytes(trimPadding(s.BaseVolume))
+	}
+	if s.AssetVolume != nil {
+		e.QuoteVolume, errs[6] = utils.ParseDecimalBytes(trimPadding(s.AssetVolume))
+	}
+	for _, err := range errs {
+		if err != nil {
+			return e, errors.Wrap(ErrInvalidKline, err.Error())
+		}
+	}
+	return e, nil
+}
+
+// parseSymbol converts dataset symbol like ETH/USDT to exchange one
+func parseSymbol(b []byte) string {
+	symbol := make([]byte, 0, len(b))
+	for _, c := range b {
+		switch {
+		case c == '/' || c == '-' || c == '_':
+			continue
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		}
+		symbol = append(symbol, c)
+	}
+	return string(symbol)
 }
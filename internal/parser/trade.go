@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"bytes"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/exchange-emulator/pkg/utils"
+)
+
+// Binance aggTrades archive columns:
+// agg trade id, price, quantity, first trade id, last trade id, time, is buyer maker, is best match
+const (
+	aggTradePrice    = 1
+	aggTradeQuantity = 2
+	aggTradeTime     = 5
+	aggTradeColumns  = 6
+)
+
+// Binance trades archive columns:
+// trade id, price, quantity, quote quantity, time, is buyer maker, is best match
+const (
+	tradePrice         = 1
+	tradeQuantity      = 2
+	tradeQuoteQuantity = 3
+	tradeTime          = 4
+	tradeColumns       = 5
+)
+
+var ErrInvalidTrade = errors.New("invalid trade")
+
+func parseAggTrade(row []byte) (ExchangeState, error) {
+	fields := bytes.Split(row, []byte{','})
+	if len(fields) < aggTradeColumns {
+		return ExchangeState{}, ErrInvalidTrade
+	}
+
+	e, err := newTick(fields[aggTradeTime], fields[aggTradePrice], fields[aggTradeQuantity])
+	if err != nil {
+		return e, err
+	}
+	e.QuoteVolume = e.Close.Mul(e.Volume)
+
+	return e, nil
+}
+
+func parseTrade(row []byte) (ExchangeState, error) {
+	fields := bytes.Split(row, []byte{','})
+	if len(fields) < tradeColumns {
+		return ExchangeState{}, ErrInvalidTrade
+	}
+
+	e, err := newTick(fields[tradeTime], fields[tradePrice], fields[tradeQuantity])
+	if err != nil {
+		return e, err
+	}
+	e.QuoteVolume, err = utils.ParseDecimalBytes(trimPadding(fields[tradeQuoteQuantity]))
+	if err != nil {
+		return e, errors.Wrap(ErrInvalidTrade, err.Error())
+	}
+
+	return e, nil
+}
+
+// newTick returns a state of one trade: all prices equal the trade price and volume is the trade quantity
+func newTick(unix, price, qty []byte) (ExchangeState, error) {
+	e := ExchangeState{Tick: true}
+
+	var errs [3]error
+	e.Unix, errs[0] = utils.ParseUintBytes(unix)
+	e.Unix = normalizeUnix(e.Unix)
+	e.Close, errs[1] = utils.ParseDecimalBytes(trimPadding(price))
+	e.Volume, errs[2] = utils.ParseDecimalBytes(trimPadding(qty))
+	for _, err := range errs {
+		if err != nil {
+			return e, errors.Wrap(ErrInvalidTrade, err.Error())
+		}
+	}
+	e.Open, e.High, e.Low = e.Close, e.Close, e.Close
+
+	return e, nil
+}
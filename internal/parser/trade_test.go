@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAggregateTrades(t *testing.T) {
+	archive := "agg_trade_id,price,quantity,first_trade_id,last_trade_id,transact_time,is_buyer_maker,is_best_match\n" +
+		"1,3676.22000000,0.50000000,1,1,1640995200100,true,true\n" +
+		"2,3680.00000000,0.25000000,2,3,1640995230000,false,true\n" +
+		"3,3675.00000000,1.00000000,4,4,1640995259999,true,true\n" +
+		"4,3677.00000000,0.10000000,5,5,1640995260001,false,true\n"
+
+	ticks, err := collect(func(emit func(e ExchangeState) error) error {
+		return scanArchive(strings.NewReader(archive), "ETHUSDT", parseAggTrade, emit)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ticks) != 4 || !ticks[0].Tick || !ticks[0].High.Equal(ticks[0].Low) {
+		t.Fatalf("unexpected ticks %+v", ticks)
+	}
+
+	var klines []ExchangeState
+	r := NewResampler("", tickInterval, 0)
+	for _, tick := range ticks[:3] {
+		r.Add(tick, func(kline ExchangeState) {
+			t.Fatalf("kline closed on tick %d", tick.Unix)
+		})
+	}
+
+	r.Add(ticks[3], func(kline ExchangeState) {
+		klines = append(klines, kline)
+	})
+	if len(klines) != 1 {
+		t.Fatal("kline is not closed by the next minute tick")
+	}
+	kline := klines[0]
+	if kline.Unix != 1640995200000 || kline.Open.String() != "3676.22" || kline.High.String() != "3680" ||
+		kline.Low.String() != "3675" || kline.Close.String() != "3675" || kline.Volume.String() != "1.75" {
+		t.Errorf("kline = %+v", kline)
+	}
+}
@@ -0,0 +1,261 @@
+package parser
+
+import (
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/config"
+)
+
+// Policies of dataset problems
+const (
+	// PolicyIgnore reports the problem and keeps the data as is
+	PolicyIgnore = "ignore"
+	// PolicyFail stops loading of the dataset
+	PolicyFail = "fail"
+	// PolicySkip drops the invalid state, gaps are left as is
+	PolicySkip = "skip"
+	// PolicyForwardFill replaces prices of the invalid state or fills the gap with flat klines of the previous close.
+	// Replaced states keep their volume, so a forward filled zero volume kline is a flat zero volume one
+	PolicyForwardFill = "forward_fill"
+	// PolicyInterpolate fills the gap with klines of linear prices between the previous close and the next open
+	PolicyInterpolate = "interpolate"
+)
+
+type Problem string
+
+const (
+	ProblemGap        Problem = "gap"
+	ProblemDuplicate  Problem = "duplicate"
+	ProblemOutOfOrder Problem = "out_of_order"
+	ProblemOHLC       Problem = "ohlc"
+	ProblemZeroVolume Problem = "zero_volume"
+)
+
+// maxReportIssues limits a number of issues kept in the report
+const maxReportIssues = 20
+
+var (
+	ErrInvalidDataset = errors.New("invalid dataset")
+	ErrInvalidPolicy  = errors.New("invalid validation policy")
+)
+
+// Issue is a problem of the state at the timestamp. Missing is a number of klines missing before it.
+type Issue struct {
+	Problem Problem
+	Unix    int64
+	Missing int64
+}
+
+// Report counts problems of the dataset and states changed by policies
+type Report struct {
+	Symbol     string
+	File       string
+	Issues     []Issue
+	States     int
+	Interval   int64
+	Gaps       int
+	Missing    int64
+	Duplicates int
+	OutOfOrder int
+	OHLC       int
+	ZeroVolume int
+	Filled     int
+	Skipped    int
+}
+
+// Valid reports whether the dataset has no problems
+func (r *Report) Valid() bool {
+	return r.Gaps+r.Duplicates+r.OutOfOrder+r.OHLC+r.ZeroVolume == 0
+}
+
+func (r *Report) add(problem Problem, unix, missing int64) {
+	switch problem {
+	case ProblemGap:
+		r.Gaps++
+		r.Missing += missing
+	case ProblemDuplicate:
+		r.Duplicates++
+	case ProblemOutOfOrder:
+		r.OutOfOrder++
+	case ProblemOHLC:
+		r.OHLC++
+	case ProblemZeroVolume:
+		r.ZeroVolume++
+	}
+	if len(r.Issues) < maxReportIssues {
+		r.Issues = append(r.Issues, Issue{Problem: problem, Unix: unix, Missing: missing})
+	}
+}
+
+// CheckPolicies returns an error if a policy can't be applied to its problem
+func CheckPolicies(cfg config.ValidationConfig) error {
+	policies := []struct {
+		policy  string
+		allowed []string
+	}{
+		{cfg.Gaps, []string{PolicyIgnore, PolicyFail, PolicySkip, PolicyForwardFill, PolicyInterpolate}},
+		{cfg.Duplicates, []string{PolicyIgnore, PolicyFail, PolicySkip}},
+		{cfg.OHLC, []string{PolicyIgnore, PolicyFail, PolicySkip, PolicyForwardFill}},
+		{cfg.ZeroVolume, []string{PolicyIgnore, PolicyFail, PolicySkip, PolicyForwardFill}},
+	}
+	for _, p := range policies {
+		if !contains(p.allowed, p.policy) {
+			return errors.Wrap(ErrInvalidPolicy, p.policy)
+		}
+	}
+	return nil
+}
+
+// validator checks states of one symbol ordered by time and applies policies to found problems.
+// States are checked one by one as they are read, valid and filled ones are sent to emit.
+// Gaps are checked for klines only, trades of the same timestamp aren't duplicates.
+// Ignored out of order states are sent as is, the cache orders them by time.
+type validator struct {
+	cfg    config.ValidationConfig
+	report *Report
+	emit   func(e ExchangeState) error
+	// last is the latest valid state, problems are checked against it,
+	// ignored out of order states don't move the time back
+	last    ExchangeState
+	started bool
+	tick    bool
+}
+
+// newValidator returns a validator of the dataset, report interval is the kline interval gaps are checked with
+func newValidator(cfg config.ValidationConfig, report *Report, emit func(e ExchangeState) error) *validator {
+	return &validator{cfg: cfg, report: report, emit: emit}
+}
+
+func (v *validator) add(e ExchangeState) error {
+	v.report.States++
+	if v.report.States == 1 {
+		v.tick = e.Tick
+	}
+	var last *ExchangeState
+	if v.started {
+		last = &v.last
+	}
+
+	if last != nil && (e.Unix < last.Unix || (!v.tick && e.Unix == last.Unix)) {
+		problem, policy := ProblemOutOfOrder, v.cfg.Duplicates
+		if e.Unix == last.Unix {
+			problem = ProblemDuplicate
+		}
+		v.report.add(problem, e.Unix, 0)
+		switch policy {
+		case PolicyFail:
+			return errors.Wrapf(ErrInvalidDataset, "%s at %d", problem, e.Unix)
+		case PolicySkip:
+			v.report.Skipped++
+			return nil
+		}
+	}
+
+	if !consistent(e) {
+		v.report.add(ProblemOHLC, e.Unix, 0)
+		if keep, err := repair(&e, last, v.cfg.OHLC, ProblemOHLC, v.report); err != nil || !keep {
+			return err
+		}
+	}
+
+	if e.Volume.IsZero() {
+		v.report.add(ProblemZeroVolume, e.Unix, 0)
+		if keep, err := repair(&e, last, v.cfg.ZeroVolume, ProblemZeroVolume, v.report); err != nil || !keep {
+			return err
+		}
+	}
+
+	interval := v.report.Interval
+	if last != nil && interval > 0 && e.Unix-last.Unix > interval {
+		missing := (e.Unix-last.Unix)/interval - 1
+		if (e.Unix-last.Unix)%interval != 0 {
+			missing++
+		}
+		v.report.add(ProblemGap, e.Unix, missing)
+		switch v.cfg.Gaps {
+		case PolicyFail:
+			return errors.Wrapf(ErrInvalidDataset, "%s of %d klines at %d", ProblemGap, missing, e.Unix)
+		case PolicyForwardFill, PolicyInterpolate:
+			for _, filled := range fillGap(*last, e, interval, v.cfg.Gaps == PolicyInterpolate) {
+				v.report.Filled++
+				if err := v.emit(filled); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if last == nil || e.Unix >= last.Unix {
+		v.last, v.started = e, true
+	}
+	return v.emit(e)
+}
+
+// repair applies the policy to the invalid state, it returns false if the state is dropped
+func repair(e, last *ExchangeState, policy string, problem Problem, report *Report) (bool, error) {
+	switch policy {
+	case PolicyFail:
+		return false, errors.Wrapf(ErrInvalidDataset, "%s at %d", problem, e.Unix)
+	case PolicySkip:
+		report.Skipped++
+		return false, nil
+	case PolicyForwardFill:
+		if last == nil {
+			report.Skipped++
+			return false, nil
+		}
+		volume, quoteVolume := e.Volume, e.QuoteVolume
+		*e = flatState(*last, last.Close, e.Unix)
+		e.Volume, e.QuoteVolume = volume, quoteVolume
+		report.Filled++
+	}
+	return true, nil
+}
+
+// fillGap returns zero volume klines between the states
+func fillGap(last, next ExchangeState, interval int64, interpolate bool) []ExchangeState {
+	var filled []ExchangeState
+	steps := (next.Unix - last.Unix) / interval
+	if (next.Unix-last.Unix)%interval == 0 {
+		steps--
+	}
+
+	exp := -minExponent(0, last.Close, next.Open)
+	delta := next.Open.Sub(last.Close)
+	for i := int64(1); i <= steps; i++ {
+		price := last.Close
+		if interpolate {
+			price = last.Close.Add(delta.Mul(decimal.New(i, 0)).Div(decimal.New(steps+1, 0))).Round(exp)
+		}
+		filled = append(filled, flatState(last, price, last.Unix+i*interval))
+	}
+	return filled
+}
+
+func flatState(last ExchangeState, price decimal.Decimal, unix int64) ExchangeState {
+	return ExchangeState{
+		Open:   price,
+		High:   price,
+		Low:    price,
+		Close:  price,
+		Symbol: last.Symbol,
+		Unix:   unix,
+	}
+}
+
+// consistent reports whether low and high bound open and close
+func consistent(e ExchangeState) bool {
+	return e.Low.LessThanOrEqual(e.High) &&
+		e.Low.LessThanOrEqual(e.Open) && e.Low.LessThanOrEqual(e.Close) &&
+		e.High.GreaterThanOrEqual(e.Open) && e.High.GreaterThanOrEqual(e.Close)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
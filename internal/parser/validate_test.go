@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/exchange-emulator/config"
+)
+
+func TestValidate(t *testing.T) {
+	const minute = 60000
+	kline := func(unix, open, high, low, close, volume int64) ExchangeState {
+		return ExchangeState{
+			Open:   decimal.New(open, 0),
+			High:   decimal.New(high, 0),
+			Low:    decimal.New(low, 0),
+			Close:  decimal.New(close, 0),
+			Volume: decimal.New(volume, 0),
+			Unix:   unix * minute,
+		}
+	}
+	ignore := config.ValidationConfig{Gaps: PolicyIgnore, Duplicates: PolicyIgnore, OHLC: PolicyIgnore, ZeroVolume: PolicyIgnore}
+
+	tests := []struct {
+		name    string
+		data    []ExchangeState
+		cfg     func(cfg *config.ValidationConfig)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: []ExchangeState{kline(0, 10, 11, 9, 10, 1), kline(1, 10, 12, 10, 11, 1)},
+			want: []string{"0:10", "1:11"},
+		},
+		{
+			name: "gap is reported",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 10, 10, 10, 10, 1), kline(4, 16, 16, 16, 16, 1)},
+			want: []string{"0:10", "1:10", "4:16"},
+		},
+		{
+			name:    "gap fails",
+			data:    []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 10, 10, 10, 10, 1), kline(4, 16, 16, 16, 16, 1)},
+			cfg:     func(cfg *config.ValidationConfig) { cfg.Gaps = PolicyFail },
+			wantErr: true,
+		},
+		{
+			name: "gap is forward filled",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 10, 10, 10, 10, 1), kline(4, 16, 16, 16, 16, 1)},
+			cfg:  func(cfg *config.ValidationConfig) { cfg.Gaps = PolicyForwardFill },
+			want: []string{"0:10", "1:10", "2:10", "3:10", "4:16"},
+		},
+		{
+			name: "gap is interpolated",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 10, 10, 10, 10, 1), kline(4, 16, 16, 16, 16, 1)},
+			cfg:  func(cfg *config.ValidationConfig) { cfg.Gaps = PolicyInterpolate },
+			want: []string{"0:10", "1:10", "2:12", "3:14", "4:16"},
+		},
+		{
+			name: "ignored out of order is sorted",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(2, 12, 12, 12, 12, 1), kline(1, 11, 11, 11, 11, 1)},
+			want: []string{"0:10", "1:11", "2:12"},
+		},
+		{
+			name: "ignored out of order isn't a gap",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 11, 11, 11, 11, 1), kline(2, 12, 12, 12, 12, 1),
+				kline(0, 13, 13, 13, 13, 1), kline(3, 14, 14, 14, 14, 1)},
+			cfg:  func(cfg *config.ValidationConfig) { cfg.Gaps = PolicyForwardFill },
+			want: []string{"0:10", "0:13", "1:11", "2:12", "3:14"},
+		},
+		{
+			name: "duplicate and out of order are skipped",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 11, 11, 11, 11, 1), kline(1, 12, 12, 12, 12, 1), kline(0, 13, 13, 13, 13, 1)},
+			cfg:  func(cfg *config.ValidationConfig) { cfg.Duplicates = PolicySkip },
+			want: []string{"0:10", "1:11"},
+		},
+		{
+			name: "inconsistent kline is skipped",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 10, 9, 11, 10, 1)},
+			cfg:  func(cfg *config.ValidationConfig) { cfg.OHLC = PolicySkip },
+			want: []string{"0:10"},
+		},
+		{
+			name: "zero volume kline is forward filled",
+			data: []ExchangeState{kline(0, 10, 10, 10, 10, 1), kline(1, 10, 20, 5, 15, 0)},
+			cfg:  func(cfg *config.ValidationConfig) { cfg.ZeroVolume = PolicyForwardFill },
+			want: []string{"0:10", "1:10"},
+		},
+		{
+			name:    "zero volume fails",
+			data:    []ExchangeState{kline(0, 10, 10, 10, 10, 0)},
+			cfg:     func(cfg *config.ValidationConfig) { cfg.ZeroVolume = PolicyFail },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ignore
+			if tt.cfg != nil {
+				tt.cfg(&cfg)
+			}
+
+			var report Report
+			data, err := validate(tt.data, cfg, &report)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDataset) {
+					t.Fatalf("validate() error = %v, want %v", err, ErrInvalidDataset)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, 0, len(data))
+			for _, e := range data {
+				got = append(got, decimal.New(e.Unix/minute, 0).String()+":"+e.Close.String())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("validate() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("validate() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPolicies(t *testing.T) {
+	cfg := config.ValidationConfig{Gaps: PolicyInterpolate, Duplicates: PolicySkip, OHLC: PolicyForwardFill, ZeroVolume: PolicyIgnore}
+	if err := CheckPolicies(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Duplicates = PolicyInterpolate
+	if err := CheckPolicies(cfg); !errors.Is(err, ErrInvalidPolicy) {
+		t.Fatalf("CheckPolicies() error = %v, want %v", err, ErrInvalidPolicy)
+	}
+}
+
+// validate runs the validator over states and orders valid ones by time as the cache does
+func validate(data []ExchangeState, cfg config.ValidationConfig, report *Report) ([]ExchangeState, error) {
+	for i := 1; i < len(data) && !data[0].Tick; i++ {
+		if step := data[i].Unix - data[i-1].Unix; step > 0 && (report.Interval == 0 || step < report.Interval) {
+			report.Interval = step
+		}
+	}
+
+	var valid []ExchangeState
+	v := newValidator(cfg, report, func(e ExchangeState) error {
+		valid = append(valid, e)
+		return nil
+	})
+	for _, e := range data {
+		if err := v.add(e); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(valid, func(i, j int) bool {
+		return valid[i].Unix < valid[j].Unix
+	})
+	return valid, nil
+}
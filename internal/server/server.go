@@ -3,9 +3,9 @@ package server
 import (
 	"context"
 	"io"
-	"os"
+	"time"
 
-	"github.com/goccy/go-json"
+	"github.com/go-faster/errors"
 	"github.com/phuslu/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -18,7 +18,7 @@ import (
 	"github.com/xenking/exchange-emulator/internal/app"
 )
 
-func New(a *app.App, cfg config.GRPCConfig, dataFile string) (*grpc.Server, error) {
+func New(a *app.App, cfg config.GRPCConfig) (*grpc.Server, error) {
 	var opts []grpc.ServerOption
 	if !cfg.DisableAuth {
 		auth := NewAuthenticator()
@@ -26,7 +26,7 @@ func New(a *app.App, cfg config.GRPCConfig, dataFile string) (*grpc.Server, erro
 	}
 	s := grpc.NewServer(opts...)
 
-	exchangeInfo, err := loadExchangeInfo(dataFile)
+	exchangeInfo, err := structpb.NewStruct(a.ExchangeInfo().Raw)
 	if err != nil {
 		return nil, err
 	}
@@ -61,74 +61,135 @@ func (s *Server) StartExchange(stream api.Multiplex_StartExchangeServer) error {
 		return err
 	}
 
-	client, err := s.app.GetOrCreateClient(ctx, userID)
+	r, err := stream.Recv()
+	if err == io.EOF {
+		log.Info().Str("user", userID).Msg("connection closed")
+		return nil
+	}
 	if err != nil {
-		return err
+		return status.Errorf(codes.Unavailable, "can't receive request: %v", err)
 	}
 
-	for {
-		r, err := stream.Recv()
-		if err == io.EOF {
-			log.Info().Str("user", userID).Msg("connection closed")
-			return nil
+	// the first request may configure the session
+	var client *app.Client
+	session, ok := r.GetRequest().(*api.Request_StartSession)
+	if ok {
+		client, err = s.app.StartSession(ctx, userID, session.StartSession)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "can't start session: %v", err)
 		}
+	} else {
+		client, err = s.app.GetOrCreateClient(ctx, userID)
 		if err != nil {
-			return status.Errorf(codes.Unavailable, "can't receive request: %v", err)
+			return err
 		}
+	}
 
+	for {
 		if client.IsClosed() {
 			return status.Error(codes.Aborted, "client is closed")
 		}
 
-		resp := &api.Response{}
-		var appErr error
-		switch req := r.GetRequest().(type) {
-		case *api.Request_CreateOrder:
-			var order *api.Order
-			order, appErr = client.CreateOrder(ctx, userID, req.CreateOrder)
-			resp.Response = &api.Response_CreateOrder{CreateOrder: order}
-		case *api.Request_CreateOrders:
-			var orders []*api.Order
-			orders, appErr = client.CreateOrders(ctx, userID, req.CreateOrders.GetOrders())
-			resp.Response = &api.Response_CreateOrders{CreateOrders: &api.Orders{Orders: orders}}
-		case *api.Request_GetOrder:
-			var order *api.Order
-			order, appErr = client.GetOrder(ctx, req.GetOrder.GetId())
-			resp.Response = &api.Response_GetOrder{GetOrder: order}
-		case *api.Request_CancelOrder:
-			appErr = client.CancelOrder(ctx, req.CancelOrder.GetId())
-			resp.Response = &api.Response_CancelOrder{CancelOrder: &emptypb.Empty{}}
-		case *api.Request_CancelOrders:
-			appErr = client.CancelOrders(ctx, req.CancelOrders.GetIds())
-			resp.Response = &api.Response_CancelOrders{CancelOrders: &emptypb.Empty{}}
-		case *api.Request_ReplaceOrder:
-			var order *api.Order
-			order, appErr = client.ReplaceOrder(ctx, userID, req.ReplaceOrder.CancelId, req.ReplaceOrder.Order)
-			resp.Response = &api.Response_ReplaceOrder{ReplaceOrder: order}
-		case *api.Request_GetBalances:
-			var balances *api.Balances
-			balances = client.GetBalances(ctx)
-			resp.Response = &api.Response_GetBalances{GetBalances: balances}
-		case *api.Request_SetBalances:
-			client.SetBalances(ctx, req.SetBalances)
-			resp.Response = &api.Response_SetBalances{SetBalances: &emptypb.Empty{}}
-		case *api.Request_GetPrice:
-			var price *api.Price
-			price = client.GetPrice(ctx, req.GetPrice.GetSymbol())
-			resp.Response = &api.Response_GetPrice{GetPrice: price}
-		case *api.Request_GetExchangeInfo:
-			resp.Response = &api.Response_GetExchangeInfo{GetExchangeInfo: s.exchangeInfo}
-		}
-
-		if appErr != nil {
-			resp.Response = &api.Response_Error{Error: &api.Error{Message: appErr.Error()}}
+		var resp *api.Response
+		if ok {
+			resp = &api.Response{Response: &api.Response_StartSession{StartSession: client.Playback(ctx)}}
+			ok = false
+		} else {
+			resp = s.handle(ctx, client, userID, r)
 		}
 		if err = stream.Send(resp); err != nil {
 			return status.Errorf(codes.Internal, "can't send response: %v", err)
 		}
+
+		r, err = stream.Recv()
+		if err == io.EOF {
+			log.Info().Str("user", userID).Msg("connection closed")
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "can't receive request: %v", err)
+		}
 	}
 }
 
+var ErrSessionStarted = errors.New("session config must be the first request")
+
+func (s *Server) handle(ctx context.Context, client *app.Client, userID string, r *api.Request) *api.Response {
+	resp := &api.Response{}
+	var appErr error
+	switch req := r.GetRequest().(type) {
+	case *api.Request_CreateOrder:
+		var order *api.Order
+		order, appErr = client.CreateOrder(ctx, userID, req.CreateOrder)
+		resp.Response = &api.Response_CreateOrder{CreateOrder: order}
+	case *api.Request_CreateOrders:
+		var orders []*api.Order
+		orders, appErr = client.CreateOrders(ctx, userID, req.CreateOrders.GetOrders())
+		resp.Response = &api.Response_CreateOrders{CreateOrders: &api.Orders{Orders: orders}}
+	case *api.Request_GetOrder:
+		var order *api.Order
+		order, appErr = client.GetOrder(ctx, req.GetOrder.GetId())
+		resp.Response = &api.Response_GetOrder{GetOrder: order}
+	case *api.Request_CancelOrder:
+		appErr = client.CancelOrder(ctx, req.CancelOrder.GetId())
+		resp.Response = &api.Response_CancelOrder{CancelOrder: &emptypb.Empty{}}
+	case *api.Request_CancelOrders:
+		appErr = client.CancelOrders(ctx, req.CancelOrders.GetIds())
+		resp.Response = &api.Response_CancelOrders{CancelOrders: &emptypb.Empty{}}
+	case *api.Request_ReplaceOrder:
+		var order *api.Order
+		order, appErr = client.ReplaceOrder(ctx, userID, req.ReplaceOrder.CancelId, req.ReplaceOrder.Order)
+		resp.Response = &api.Response_ReplaceOrder{ReplaceOrder: order}
+	case *api.Request_CreateOco:
+		var list *api.OrderList
+		list, appErr = client.CreateOrderList(ctx, userID, req.CreateOco)
+		resp.Response = &api.Response_CreateOco{CreateOco: list}
+	case *api.Request_GetBalances:
+		var balances *api.Balances
+		balances = client.GetBalances(ctx)
+		resp.Response = &api.Response_GetBalances{GetBalances: balances}
+	case *api.Request_SetBalances:
+		client.SetBalances(ctx, req.SetBalances)
+		resp.Response = &api.Response_SetBalances{SetBalances: &emptypb.Empty{}}
+	case *api.Request_GetPrice:
+		var price *api.Price
+		price = client.GetPrice(ctx, req.GetPrice.GetSymbol())
+		resp.Response = &api.Response_GetPrice{GetPrice: price}
+	case *api.Request_GetOrderBook:
+		var orderBook *api.OrderBook
+		orderBook, appErr = client.GetOrderBook(ctx, req.GetOrderBook.GetSymbol(), int(req.GetOrderBook.GetLimit()))
+		resp.Response = &api.Response_GetOrderBook{GetOrderBook: orderBook}
+	case *api.Request_Pause:
+		resp.Response = &api.Response_Pause{Pause: client.Pause(ctx)}
+	case *api.Request_Resume:
+		resp.Response = &api.Response_Resume{Resume: client.Resume(ctx)}
+	case *api.Request_Seek:
+		var playback *api.Playback
+		playback, appErr = client.SeekTime(ctx, req.Seek.GetUnix())
+		resp.Response = &api.Response_Seek{Seek: playback}
+	case *api.Request_SetDelay:
+		var playback *api.Playback
+		playback, appErr = client.SetDelay(ctx, time.Duration(req.SetDelay.GetDelayUs())*time.Microsecond)
+		resp.Response = &api.Response_SetDelay{SetDelay: playback}
+	case *api.Request_Advance:
+		var playback *api.Playback
+		playback, appErr = client.Advance(ctx, int(req.Advance.GetN()))
+		resp.Response = &api.Response_Advance{Advance: playback}
+	case *api.Request_GetClock:
+		resp.Response = &api.Response_GetClock{GetClock: client.GetClock(ctx)}
+	case *api.Request_StartSession:
+		appErr = ErrSessionStarted
+	case *api.Request_GetExchangeInfo:
+		resp.Response = &api.Response_GetExchangeInfo{GetExchangeInfo: s.exchangeInfo}
+	}
+
+	if appErr != nil {
+		resp.Response = &api.Response_Error{Error: &api.Error{Message: appErr.Error()}}
+	}
+
+	return resp
+}
+
 func contextError(ctx context.Context) error {
 	switch ctx.Err() {
 	case context.Canceled:
@@ -139,15 +200,3 @@ func contextError(ctx context.Context) error {
 		return nil
 	}
 }
-
-func loadExchangeInfo(filename string) (*structpb.Struct, error) {
-	f, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	info := make(map[string]interface{})
-	err = json.NewDecoder(f).Decode(&info)
-
-	return structpb.NewStruct(info)
-}
@@ -7,10 +7,12 @@ import (
 )
 
 type UserConn struct {
-	conn   *websocket.Conn
-	close  chan struct{}
-	ID     string
-	closed int32
+	conn  *websocket.Conn
+	close chan struct{}
+	ID    string
+	// Intervals are kline intervals requested by the connection
+	Intervals []string
+	closed    int32
 }
 
 func (c *UserConn) Send(data []byte) error {
@@ -70,8 +70,11 @@ func (s *Server) CloseConn(conn *websocket.Conn, err error) {
 }
 
 type initConn struct {
-	UserID      string `json:"user_id"`
-	Initialized bool   `json:"initialized,omitempty"`
+	UserID string `json:"user_id"`
+	// Intervals are kline intervals like 5m or 1h sent to the prices WS in addition to the base klines,
+	// their frames start with parser.KlineFrame byte
+	Intervals   []string `json:"intervals,omitempty"`
+	Initialized bool     `json:"initialized,omitempty"`
 }
 
 var (
@@ -114,10 +117,11 @@ func (s *Server) OnData(conn *websocket.Conn, _ bool, data []byte) {
 	}
 
 	uc := &UserConn{
-		conn:   conn,
-		ID:     init.UserID,
-		close:  make(chan struct{}),
-		closed: 0,
+		conn:      conn,
+		ID:        init.UserID,
+		Intervals: init.Intervals,
+		close:     make(chan struct{}),
+		closed:    0,
 	}
 	s.conns.Set(init.UserID, uc)
 	s.users <- uc
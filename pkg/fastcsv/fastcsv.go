@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"reflect"
+	"strings"
 	"syscall"
 )
 
@@ -41,11 +42,20 @@ func NewFileReader(filename string, separator byte, dest interface{}) (*FileRead
 
 	unsetFields := make(map[string]bool)
 	destFields := make(map[string]reflect.Value)
+	// tags ending with * match columns by prefix, fields with the same tag take matching columns in order
+	var prefixes []string
+	prefixFields := make(map[string][]reflect.Value)
 	destValue := reflect.Indirect(reflect.ValueOf(dest))
 	for i := 0; i < destValue.NumField(); i++ {
 		field := destValue.Type().Field(i)
 
-		if name, ok := field.Tag.Lookup("csv"); ok {
+		if name, ok := field.Tag.Lookup("csv"); ok && strings.HasSuffix(name, "*") {
+			prefix := strings.TrimSuffix(name, "*")
+			if _, ok = prefixFields[prefix]; !ok {
+				prefixes = append(prefixes, prefix)
+			}
+			prefixFields[prefix] = append(prefixFields[prefix], destValue.Field(i))
+		} else if ok {
 			destFields[name] = destValue.Field(i)
 		} else {
 			destFields[field.Name] = destValue.Field(i)
@@ -58,6 +68,12 @@ func NewFileReader(filename string, separator byte, dest interface{}) (*FileRead
 	r.dest = make([]reflect.Value, len(header))
 	for index, name := range header {
 		field, ok := destFields[string(name)]
+		for _, prefix := range prefixes {
+			if fields := prefixFields[prefix]; !ok && len(fields) > 0 && bytes.HasPrefix(name, []byte(prefix)) {
+				field, ok = fields[0], true
+				prefixFields[prefix] = fields[1:]
+			}
+		}
 		if !ok {
 			continue
 		}